@@ -0,0 +1,10 @@
+resource "netris_ip_reservation" "my-vip" {
+  subnetid    = netris_subnet.my-subnet-common.id
+  description = "VIP for my-service"
+}
+
+resource "netris_ip_reservation" "my-bgp-peer" {
+  subnetid    = netris_subnet.my-subnet-common.id
+  address     = "203.0.113.10"
+  description = "BGP peer"
+}
@@ -0,0 +1,8 @@
+resource "netris_ipam_prefix_allocation" "my-tenant-subnet" {
+  name         = "my-tenant-subnet"
+  allocationid = netris_allocation.my-allocation-vnet2.id
+  prefixlength = 27
+  tenantid     = data.netris_tenant.admin.id
+  purpose      = "common"
+  siteids      = [netris_site.santa-clara.id]
+}
@@ -7,10 +7,10 @@ resource "netris_l4lb" "my_l4lb" {
   frontend = "203.0.113.150"
   port     = 8443
   backend  = ["198.18.51.100:443", "198.18.51.101:443"]
-  check = {
+  check {
     type        = "http"
     timeout     = 3000
-    requestPath = "/"
+    requestpath = "/"
   }
   depends_on = [netris_subnet.my-subnet-load-balancer, netris_subnet.my-subnet-vnet]
 }
@@ -23,10 +23,10 @@ resource "netris_l4lb" "my_l4lb-in-vpc" {
   protocol = "tcp"
   port     = 8443
   backend  = ["198.18.51.102:443", "198.18.51.103:443"]
-  check = {
+  check {
     type        = "http"
     timeout     = 3000
-    requestPath = "/"
+    requestpath = "/"
   }
   vpcid      = netris_vpc.my-vpc.id
   depends_on = [netris_subnet.my-subnet-load-balancer, netris_subnet.my-subnet-vnet-in-my-vpc]
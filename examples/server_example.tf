@@ -27,3 +27,12 @@ resource "netris_server" "my-server02" {
   role       = "hyperv_cs"
   depends_on = [netris_subnet.my-subnet-mgmt, netris_subnet.my-subnet-loopback]
 }
+
+resource "netris_server" "my-server03" {
+  name        = "my-server03"
+  tenantid    = data.netris_tenant.admin.id
+  siteid      = netris_site.santa-clara.id
+  description = "Server 03"
+  portcount   = 2
+  depends_on  = [netris_subnet.my-subnet-mgmt, netris_subnet.my-subnet-loopback]
+}
@@ -0,0 +1,4 @@
+resource "netris_servercluster_member" "my-server03" {
+  clusterid = netris_servercluster.my-servercluster1.id
+  serverid  = netris_server.my-server03.id
+}
@@ -0,0 +1,8 @@
+resource "netris_vnet_gateway" "my-vnet-gw" {
+  vnetid          = netris_vnet.my-vnet.id
+  prefix          = "203.0.113.1/25"
+  dhcp            = "enabled"
+  dhcpoptionsetid = netris_dhcp_option_set.dhcp_option_set.id
+  dhcpstartip     = "203.0.113.10"
+  dhcpendip       = "203.0.113.100"
+}
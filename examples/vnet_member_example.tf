@@ -0,0 +1,4 @@
+resource "netris_vnet_member" "my-server01-nic1" {
+  vnetid = netris_vnet.my-vnet.id
+  port   = "swp10@my-switch01"
+}
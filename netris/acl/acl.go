@@ -25,6 +25,7 @@ import (
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v1/types/acl"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -77,7 +78,8 @@ func Resource() *schema.Resource {
 				Description: "For TCP, also match reverse packets except with TCP SYN flag. For non-TCP, also generate a reverse rule with swapped source/destination. Default value is `true`",
 			},
 			"srcprefix": {
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Required:     true,
 				Type:         schema.TypeString,
 				Description:  "Source IPv4/IPv6 address. Example `192.0.2.0/24`",
@@ -100,7 +102,8 @@ func Resource() *schema.Resource {
 				Description: "Match source ports on a group of ports. Valid value name of ACL Port Group",
 			},
 			"dstprefix": {
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Required:     true,
 				Type:         schema.TypeString,
 				Description:  "Destination IPv4/IPv6 address. Example `0.0.0.0/0`",
@@ -0,0 +1,287 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acl
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v1/types/acl"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func ListDataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: ACLs",
+		Schema: map[string]*schema.Schema{
+			"prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Return only ACLs whose source or destination prefix overlaps this prefix. Example: `10.0.0.0/24`",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAction,
+				Description:  "Return only ACLs with this action. Possible values: `permit`, `deny`.",
+			},
+			"proto": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateProto,
+				Description:  "Return only ACLs matching this IP protocol. Possible values: `all`, `ip`, `tcp`, `udp`, `icmp`, `icmpv6`.",
+			},
+			"portrange": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePortRange,
+				Description:  "Return only ACLs whose source or destination ports overlap this port or port range. ACLs without ports match every port. Example: `443` or `8000-8100`",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching ACLs.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ACL ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ACL name.",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`permit` or `deny`.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ACL comment.",
+						},
+						"proto": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Matched IP protocol.",
+						},
+						"srcprefix": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Source prefix.",
+						},
+						"srcportfrom": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Source port range start. `0` when a port group or no ports are used.",
+						},
+						"srcportto": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Source port range end. `0` when a port group or no ports are used.",
+						},
+						"srcportgroup": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Source port group name.",
+						},
+						"dstprefix": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Destination prefix.",
+						},
+						"dstportfrom": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Destination port range start. `0` when a port group or no ports are used.",
+						},
+						"dstportto": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Destination port range end. `0` when a port group or no ports are used.",
+						},
+						"dstportgroup": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Destination port group name.",
+						},
+						"reverse": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the ACL also matches the reverse direction.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ACL status as reported by the controller.",
+						},
+					},
+				},
+			},
+		},
+		Read: listDataResourceRead,
+	}
+}
+
+type portRange struct {
+	from int
+	to   int
+}
+
+// aclPorts returns the port ranges one side of an ACL matches. Explicit
+// ranges win over port groups; no ports at all means every port.
+func aclPorts(from, to int, groupPorts string) []portRange {
+	if from != 0 || to != 0 {
+		if to == 0 {
+			to = from
+		}
+		return []portRange{{from, to}}
+	}
+	if groupPorts != "" {
+		ranges := []portRange{}
+		for _, p := range strings.Split(groupPorts, ",") {
+			if f, t, err := parsePortRange(strings.TrimSpace(p)); err == nil {
+				ranges = append(ranges, portRange{f, t})
+			}
+		}
+		return ranges
+	}
+	return []portRange{{1, 65535}}
+}
+
+func portsOverlap(ranges []portRange, from, to int) bool {
+	for _, r := range ranges {
+		if r.from <= to && from <= r.to {
+			return true
+		}
+	}
+	return false
+}
+
+func prefixOverlaps(addr string, length int, p netip.Prefix) bool {
+	ap, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", addr, length))
+	if err != nil {
+		return false
+	}
+	return ap.Overlaps(p)
+}
+
+func listDataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	action := d.Get("action").(string)
+	proto := d.Get("proto").(string)
+
+	var prefix netip.Prefix
+	if p := d.Get("prefix").(string); p != "" {
+		var err error
+		if strings.Contains(p, "/") {
+			prefix, err = netip.ParsePrefix(p)
+		} else {
+			var addr netip.Addr
+			addr, err = netip.ParseAddr(p)
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	var portFrom, portTo int
+	if r := d.Get("portrange").(string); r != "" {
+		var err error
+		portFrom, portTo, err = parsePortRange(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	acls, err := clientset.ACL().Get()
+	if err != nil {
+		return err
+	}
+
+	pgNames := make(map[int]string)
+	pgs, err := clientset.PortGroup().Get()
+	if err != nil {
+		return err
+	}
+	for _, pg := range pgs {
+		pgNames[pg.ID] = pg.Name
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, a := range acls {
+		if action != "" && a.Action != action {
+			continue
+		}
+		if proto != "" && a.Protocol != proto {
+			continue
+		}
+		if prefix.IsValid() && !prefixOverlaps(a.SrcPrefix, a.SrcLength, prefix) && !prefixOverlaps(a.DstPrefix, a.DstLength, prefix) {
+			continue
+		}
+		if portFrom > 0 && !matchesPorts(a, portFrom, portTo) {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(a.ID))
+		items = append(items, map[string]interface{}{
+			"id":           a.ID,
+			"name":         a.Name,
+			"action":       a.Action,
+			"comment":      a.Comment,
+			"proto":        a.Protocol,
+			"srcprefix":    fmt.Sprintf("%s/%d", a.SrcPrefix, a.SrcLength),
+			"srcportfrom":  a.SrcPortFrom,
+			"srcportto":    a.SrcPortTo,
+			"srcportgroup": pgNames[a.SrcPortGroup],
+			"dstprefix":    fmt.Sprintf("%s/%d", a.DstPrefix, a.DstLength),
+			"dstportfrom":  a.DstPortFrom,
+			"dstportto":    a.DstPortTo,
+			"dstportgroup": pgNames[a.DstPortGroup],
+			"reverse":      a.Reverse == "yes",
+			"status":       a.Status,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func matchesPorts(a *acl.ACL, from, to int) bool {
+	return portsOverlap(aclPorts(a.SrcPortFrom, a.SrcPortTo, a.AclSrcGrpPorts), from, to) ||
+		portsOverlap(aclPorts(a.DstPortFrom, a.DstPortTo, a.AclDstGrpPorts), from, to)
+}
@@ -18,19 +18,11 @@ package acl
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
-func validateIPPrefix(val interface{}, key string) (warns []string, errs []error) {
-	v := val.(string)
-	re := regexp.MustCompile(`(^(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)(\/([0-9]|[12]\d|3[0-2]))?$)|(^((([0-9A-Fa-f]{1,4}:){7}([0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){6}(:[0-9A-Fa-f]{1,4}|((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(([0-9A-Fa-f]{1,4}:){5}(((:[0-9A-Fa-f]{1,4}){1,2})|:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(([0-9A-Fa-f]{1,4}:){4}(((:[0-9A-Fa-f]{1,4}){1,3})|((:[0-9A-Fa-f]{1,4})?:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){3}(((:[0-9A-Fa-f]{1,4}){1,4})|((:[0-9A-Fa-f]{1,4}){0,2}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){2}(((:[0-9A-Fa-f]{1,4}){1,5})|((:[0-9A-Fa-f]{1,4}){0,3}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){1}(((:[0-9A-Fa-f]{1,4}){1,6})|((:[0-9A-Fa-f]{1,4}){0,4}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(:(((:[0-9A-Fa-f]{1,4}){1,7})|((:[0-9A-Fa-f]{1,4}){0,5}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:)))(%.+)?(\/([0-9]{1,2}|1[01][0-9]|12[0-8]))?$)`)
-	if !re.Match([]byte(v)) {
-		errs = append(errs, fmt.Errorf("invalid %s: %s", key, v))
-	}
-	return warns, errs
-}
-
 func validateProto(val interface{}, key string) (warns []string, errs []error) {
 	v := val.(string)
 	if !(v == "all" || v == "ip" || v == "tcp" || v == "udp" || v == "icmp" || v == "icmpv6") {
@@ -74,3 +66,39 @@ func validateDate(val interface{}, key string) (warns []string, errs []error) {
 	}
 	return warns, errs
 }
+
+func validateAction(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !(v == "permit" || v == "deny") {
+		errs = append(errs, fmt.Errorf("'%s' must be permit or deny, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+func validatePortRange(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, _, err := parsePortRange(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid %s: %s", key, err))
+	}
+	return warns, errs
+}
+
+// parsePortRange parses a single port (`80`) or a port range (`8000-8100`).
+func parsePortRange(v string) (from, to int, err error) {
+	parts := strings.SplitN(v, "-", 2)
+	from, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("'%s' is not a port or a port range", v)
+	}
+	to = from
+	if len(parts) == 2 {
+		to, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return 0, 0, fmt.Errorf("'%s' is not a port or a port range", v)
+		}
+	}
+	if from < 1 || to > 65535 || from > to {
+		return 0, 0, fmt.Errorf("'%s' must be within 1-65535 and ascending", v)
+	}
+	return from, to, nil
+}
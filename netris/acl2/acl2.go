@@ -24,6 +24,7 @@ import (
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v1/types/acl2"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -81,7 +82,9 @@ func Resource() *schema.Resource {
 							Optional:    true,
 							Description: "List with prefixes",
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:         schema.TypeString,
+								ValidateFunc: ipaddr.ValidatePrefix,
+								StateFunc:    ipaddr.StateFunc,
 							},
 						},
 						"protocol": {
@@ -137,9 +140,11 @@ func Resource() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"prefix": {
-										Required:    true,
-										Type:        schema.TypeString,
-										Description: "Valid prefix",
+										Required:     true,
+										Type:         schema.TypeString,
+										ValidateFunc: ipaddr.ValidatePrefix,
+										StateFunc:    ipaddr.StateFunc,
+										Description:  "Valid prefix",
 									},
 									"comment": {
 										Optional:    true,
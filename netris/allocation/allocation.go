@@ -24,6 +24,7 @@ import (
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	api "github.com/netrisai/netriswebapi/v2"
 
@@ -40,9 +41,11 @@ func Resource() *schema.Resource {
 				Description: "Unique name for current allocation.",
 			},
 			"prefix": {
-				Required:    true,
-				Type:        schema.TypeString,
-				Description: "Unique prefix for allocation, must not overlap with other allocations.",
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Unique prefix for allocation, must not overlap with other allocations.",
 			},
 			"tenantid": {
 				ForceNew:    true,
@@ -147,7 +150,7 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	id, _ := strconv.Atoi(d.Id())
-	ipam := getByID(ipams, id)
+	ipam := GetByID(ipams, id)
 	if ipam == nil {
 		return nil
 	}
@@ -241,7 +244,7 @@ func resourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 		return false, err
 	}
 	id, _ := strconv.Atoi(d.Id())
-	if ipam := getByID(ipams, id); ipam == nil {
+	if ipam := GetByID(ipams, id); ipam == nil {
 		return false, nil
 	}
 
@@ -257,7 +260,7 @@ func resourceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceDa
 		return []*schema.ResourceData{d}, err
 	}
 	prefix := d.Id()
-	ipam := getByPrefix(ipams, prefix)
+	ipam := GetByPrefix(ipams, prefix)
 	if ipam == nil {
 		return []*schema.ResourceData{d}, fmt.Errorf("allocation '%s' not found", prefix)
 	}
@@ -267,12 +270,12 @@ func resourceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceDa
 	return []*schema.ResourceData{d}, nil
 }
 
-func getByPrefix(list []*ipam.IPAM, prefix string) *ipam.IPAM {
+func GetByPrefix(list []*ipam.IPAM, prefix string) *ipam.IPAM {
 	for _, s := range list {
 		if s.Prefix == prefix && s.Type == "allocation" {
 			return s
 		} else if len(s.Children) > 0 {
-			if p := getByPrefix(s.Children, prefix); p != nil {
+			if p := GetByPrefix(s.Children, prefix); p != nil {
 				return p
 			}
 		}
@@ -280,12 +283,12 @@ func getByPrefix(list []*ipam.IPAM, prefix string) *ipam.IPAM {
 	return nil
 }
 
-func getByID(list []*ipam.IPAM, id int) *ipam.IPAM {
+func GetByID(list []*ipam.IPAM, id int) *ipam.IPAM {
 	for _, s := range list {
 		if s.ID == id && s.Type == "allocation" {
 			return s
 		} else if len(s.Children) > 0 {
-			if p := getByID(s.Children, id); p != nil {
+			if p := GetByID(s.Children, id); p != nil {
 				return p
 			}
 		}
@@ -27,6 +27,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/bgp"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+	"github.com/netrisai/terraform-provider-netris/netris/vlancheck"
 
 	api "github.com/netrisai/netriswebapi/v2"
 )
@@ -78,13 +80,15 @@ func Resource() *schema.Resource {
 				Description: "VLAN ID for tagging BGP neighbor facing ethernet frames. Valid values should be in range 2-4094.",
 			},
 			"localip": {
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Required:     true,
 				Type:         schema.TypeString,
 				Description:  "BGP session local IP. Example `10.0.1.1/24`.",
 			},
 			"remoteip": {
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Required:     true,
 				Type:         schema.TypeString,
 				Description:  "BGP session remote IP. Example `10.0.1.2/24`.",
@@ -115,9 +119,10 @@ func Resource() *schema.Resource {
 				Description:  "Valid value is `enabled` or `disabled`; enabled - initiating and waiting for BGP connections, disabled - disable Layer-2 tunnel and Layer-3 address. Default value is `enabled`.",
 			},
 			"multihop": {
-				Optional:    true,
-				Type:        schema.TypeMap,
-				Description: "Multihop BGP session configurations.",
+				Optional:         true,
+				Type:             schema.TypeMap,
+				DiffSuppressFunc: ipaddr.DiffSuppress,
+				Description:      "Multihop BGP session configurations.",
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
 					Optional:     true,
@@ -245,7 +250,40 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceImport,
 		},
+		CustomizeDiff: customizeDiff,
+	}
+}
+
+// customizeDiff checks the VLAN ID against the vlanrange of the site and,
+// when the session is on a switch port, against the VLANs V-Nets and other
+// BGP sessions already use on that port.
+func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	// Unchanged sessions are left alone, so that a narrowed vlanrange or a
+	// later V-Net on the port doesn't block their plans.
+	if d.Id() != "" && !d.HasChange("vlanid") && !d.HasChange("siteid") && !d.HasChange("portid") {
+		return nil
 	}
+	if !d.NewValueKnown("vlanid") || !d.NewValueKnown("siteid") || !d.NewValueKnown("portid") {
+		return nil
+	}
+
+	vlanID := d.Get("vlanid").(int)
+	if vlanID <= 1 {
+		return nil
+	}
+
+	checker := vlancheck.New(m.(*api.Clientset))
+	err := checker.SiteRange(d.Get("siteid").(int), vlanID, vlanID)
+	if err != nil {
+		return err
+	}
+
+	portID := d.Get("portid").(int)
+	if portID <= 0 {
+		return nil
+	}
+	id, _ := strconv.Atoi(d.Id())
+	return checker.Port(portID, "", vlanID, vlanID, vlancheck.Owner{Kind: "bgp", ID: id})
 }
 
 func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
@@ -0,0 +1,226 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bgp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/netrisai/netriswebapi/v2/types/bgp"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// dataAttributes returns the computed attributes shared by the netris_bgp
+// data source and the items of netris_bgps.
+func dataAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"siteid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Site (data center) ID where this BGP session is terminated on.",
+		},
+		"vpcid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "ID of VPC.",
+		},
+		"hardware": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Hardware the BGP session is terminated on.",
+		},
+		"neighboras": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "BGP neighbor AS number.",
+		},
+		"localasn": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Local AS number.",
+		},
+		"portid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Switch port ID the session is terminated on. `0` when the session is terminated on a VNet.",
+		},
+		"vnetid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "VNet ID the session is terminated on. `0` when the session is terminated on a port.",
+		},
+		"vlanid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "VLAN ID of the session.",
+		},
+		"localip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Local BGP peer IP address with prefix length.",
+		},
+		"remoteip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Remote BGP peer IP address with prefix length.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "BGP session description.",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Administrative state of the session. `enabled` or `disabled`.",
+		},
+		"bgpstate": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Operational state of the session as reported by the controller. Example: `Established`",
+		},
+		"uptime": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time since the session entered its current state, as reported by the controller.",
+		},
+		"prefixesreceived": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of prefixes received from the neighbor.",
+		},
+		"prefixesadvertised": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of prefixes advertised to the neighbor. `0` when the controller doesn't report it.",
+		},
+	}
+}
+
+func DataResource() *schema.Resource {
+	s := dataAttributes()
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+		Description:  "The ID of the BGP session.",
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+		Description:  "The name of the BGP session.",
+	}
+	s["vpcid"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+		Description: "ID of VPC to search the session in. If not specified, sessions of all VPCs are searched.",
+	}
+
+	return &schema.Resource{
+		Description: "Data Source: BGPs",
+		Schema:      s,
+		Read:        dataResourceRead,
+	}
+}
+
+// parsePrefixes splits the controller's prefix counter, reported either as
+// "received" or as "received/advertised".
+func parsePrefixes(s string) (received, advertised int) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	received, _ = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if len(parts) == 2 {
+		advertised, _ = strconv.Atoi(strings.TrimSpace(parts[1]))
+	}
+	return received, advertised
+}
+
+func flattenBGP(b *bgp.EBGP) map[string]interface{} {
+	received, advertised := parsePrefixes(b.BgpPrefixes)
+
+	vnetID := 0
+	if a, ok := b.Vnet.ID.(float64); ok {
+		vnetID = int(a)
+	}
+
+	return map[string]interface{}{
+		"siteid":             b.SiteID,
+		"vpcid":              b.Vpc.ID,
+		"hardware":           b.TermSwName,
+		"neighboras":         b.NeighborAs,
+		"localasn":           b.LocalAsn,
+		"portid":             b.Port.ID,
+		"vnetid":             vnetID,
+		"vlanid":             b.Vlan,
+		"localip":            fmt.Sprintf("%s/%d", b.LocalIP, b.PrefixLength),
+		"remoteip":           fmt.Sprintf("%s/%d", b.RemoteIP, b.PrefixLength),
+		"description":        b.Description,
+		"state":              b.Status,
+		"bgpstate":           b.BgpState,
+		"uptime":             b.BgpUptime,
+		"prefixesreceived":   received,
+		"prefixesadvertised": advertised,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	id := d.Get("id").(string)
+	name := d.Get("name").(string)
+	vpcid := d.Get("vpcid").(int)
+
+	var bgps []*bgp.EBGP
+	var err error
+	if vpcid > 0 {
+		bgps, err = clientset.BGP().GetByVpc(vpcid)
+	} else {
+		bgps, err = clientset.BGP().Get()
+	}
+	if err != nil {
+		return err
+	}
+
+	found, err := lookup.Find(bgps, id, name,
+		func(b *bgp.EBGP) int { return b.ID },
+		func(b *bgp.EBGP) string { return b.Name },
+		"bgp session", "id or vpcid")
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(found.ID))
+	err = d.Set("name", found.Name)
+	if err != nil {
+		return err
+	}
+	for k, v := range flattenBGP(found) {
+		err = d.Set(k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
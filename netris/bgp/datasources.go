@@ -0,0 +1,120 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bgp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/netrisai/netriswebapi/v2/types/bgp"
+
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+func ListDataResource() *schema.Resource {
+	item := dataAttributes()
+	item["id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The ID of the BGP session.",
+	}
+	item["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the BGP session.",
+	}
+
+	return &schema.Resource{
+		Description: "Data Source: BGPs",
+		Schema: map[string]*schema.Schema{
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only sessions of this site.",
+			},
+			"vpcid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only sessions of this VPC.",
+			},
+			"bgpstate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only sessions in this operational state (case-insensitive). Example: `Established`",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching BGP sessions.",
+				Elem: &schema.Resource{
+					Schema: item,
+				},
+			},
+		},
+		Read: listDataResourceRead,
+	}
+}
+
+func listDataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	siteID := d.Get("siteid").(int)
+	vpcid := d.Get("vpcid").(int)
+	state := d.Get("bgpstate").(string)
+
+	var bgps []*bgp.EBGP
+	var err error
+	if vpcid > 0 {
+		bgps, err = clientset.BGP().GetByVpc(vpcid)
+	} else {
+		bgps, err = clientset.BGP().Get()
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, b := range bgps {
+		if siteID > 0 && b.SiteID != siteID {
+			continue
+		}
+		if vpcid > 0 && b.Vpc.ID != vpcid {
+			continue
+		}
+		if state != "" && !strings.EqualFold(b.BgpState, state) {
+			continue
+		}
+
+		item := flattenBGP(b)
+		item["id"] = b.ID
+		item["name"] = b.Name
+
+		ids = append(ids, strconv.Itoa(b.ID))
+		items = append(items, item)
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
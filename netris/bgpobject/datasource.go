@@ -21,6 +21,7 @@ import (
 	"strconv"
 
 	"github.com/netrisai/netriswebapi/v1/types/bgpobject"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 
 	api "github.com/netrisai/netriswebapi/v2"
 
@@ -31,10 +32,19 @@ func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data Source: BGP Objects",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the BGP Object",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the BGP Object",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the BGP Object",
 			},
 			"type": {
 				Type:        schema.TypeString,
@@ -58,13 +68,13 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	obj, ok := findByName(d.Get("name").(string), clientset)
-	if !ok {
-		return fmt.Errorf("Coudn't find bgp object '%s'", d.Get("name").(string))
+	obj, err := findBGPObject(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
 	}
 
 	d.SetId(strconv.Itoa(obj.ID))
-	err := d.Set("name", obj.Name)
+	err = d.Set("name", obj.Name)
 	if err != nil {
 		return err
 	}
@@ -79,6 +89,18 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func findBGPObject(clientset *api.Clientset, id, name string) (*bgpobject.BGPObject, error) {
+	list, err := clientset.BGPObject().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, id, name,
+		func(obj *bgpobject.BGPObject) int { return obj.ID },
+		func(obj *bgpobject.BGPObject) string { return obj.Name },
+		"bgp object", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	clientset := m.(*api.Clientset)
 	var ok bool
@@ -25,6 +25,7 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/inventory"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -56,6 +57,7 @@ func Resource() *schema.Resource {
 			},
 			"mainip": {
 				Type:        schema.TypeString,
+				StateFunc:   ipaddr.StateFunc,
 				Required:    true,
 				Description: "A unique IP address which will be used as a loopback address of this unit. Valid value is ip address (example `198.51.100.10`) or `auto`. If set `auto` the controller will assign an ip address automatically from subnets with relevant purpose.",
 			},
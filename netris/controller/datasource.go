@@ -0,0 +1,135 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/inventory"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Controllers",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the controller",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the controller",
+			},
+			"tenantid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of tenant. Users of this tenant are permitted to edit this unit.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The site ID where this controller belongs.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Controller description.",
+			},
+			"mainip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The loopback address of the controller.",
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	hw, err := findController(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hw.ID))
+	err = d.Set("name", hw.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("tenantid", hw.Tenant.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("siteid", hw.Site.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("description", hw.Description)
+	if err != nil {
+		return err
+	}
+	err = d.Set("mainip", hw.MainAddress)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func findController(clientset *api.Clientset, id, name string) (*inventory.HW, error) {
+	if id != "" {
+		hwID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid controller id '%s'", id)
+		}
+		hw, err := clientset.Inventory().GetByID(hwID)
+		if err != nil || hw == nil || hw.ID == 0 || hw.Type != "controller" {
+			return nil, fmt.Errorf("couldn't find controller with id '%s'", id)
+		}
+		return hw, nil
+	}
+
+	list, err := clientset.Inventory().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	controllers := []*inventory.HW{}
+	for _, hw := range list {
+		if hw.Type == "controller" {
+			controllers = append(controllers, hw)
+		}
+	}
+
+	return lookup.Find(controllers, "", name,
+		func(hw *inventory.HW) int { return hw.ID },
+		func(hw *inventory.HW) string { return hw.Name },
+		"controller", "id")
+}
@@ -17,22 +17,31 @@ limitations under the License.
 package dhcpoptionset
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/dhcp"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 )
 
 func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Creates and manages DHCP Option Set",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of DHCP Option Set.",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "User assigned name of DHCP Option Set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "User assigned name of DHCP Option Set.",
 			},
 			"description": {
 				Optional:    true,
@@ -120,26 +129,11 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	name := d.Get("name").(string)
-
-	var apiDHCP *dhcp.DHCPOptionSet
-
-	list, err := clientset.DHCP().Get()
+	apiDHCP, err := findDHCPOptionSet(clientset, d.Get("id").(string), d.Get("name").(string))
 	if err != nil {
 		return err
 	}
 
-	for _, v := range list {
-		if v.Name == name {
-			apiDHCP = v
-			break
-		}
-	}
-
-	if apiDHCP == nil {
-		return fmt.Errorf("Coudn't find dhcp %s", name)
-	}
-
 	d.SetId(strconv.Itoa(apiDHCP.ID))
 	err = d.Set("name", apiDHCP.Name)
 	if err != nil {
@@ -193,6 +187,18 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func findDHCPOptionSet(clientset *api.Clientset, id, name string) (*dhcp.DHCPOptionSet, error) {
+	list, err := clientset.DHCP().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, id, name,
+		func(v *dhcp.DHCPOptionSet) int { return v.ID },
+		func(v *dhcp.DHCPOptionSet) string { return v.Name },
+		"dhcp option set", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	clientset := m.(*api.Clientset)
 
@@ -0,0 +1,210 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/inventory"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Inventory",
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateType,
+				Description:  "Return only units of this type. Possible values: `switch`, `softgate`, `server`, `controller`.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only units of this site.",
+			},
+			"tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only units having this tag.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only units having this role.",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching inventory units.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The unit ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit name.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit type.",
+						},
+						"tenantid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of tenant. Users of this tenant are permitted to edit this unit.",
+						},
+						"siteid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The site ID where this unit belongs.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit description.",
+						},
+						"mainip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The loopback address of the unit.",
+						},
+						"mgmtip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The out of band management address of the unit.",
+						},
+						"asnumber": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit AS number.",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit role.",
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func validateType(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !(v == "switch" || v == "softgate" || v == "server" || v == "controller") {
+		errs = append(errs, fmt.Errorf("'%s' must be switch, softgate, server or controller, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+// hwRole returns the role of the unit, which the controller keeps in a
+// different field for every unit type.
+func hwRole(hw *inventory.HW) string {
+	switch hw.Type {
+	case "switch":
+		return hw.SWRole
+	case "softgate":
+		return hw.SGRole
+	case "server":
+		return hw.SRVRole
+	}
+	return ""
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	hwType := d.Get("type").(string)
+	siteID := d.Get("siteid").(int)
+	tag := d.Get("tag").(string)
+	role := d.Get("role").(string)
+
+	list, err := clientset.Inventory().Get()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, hw := range list {
+		if hwType != "" && hw.Type != hwType {
+			continue
+		}
+		if siteID > 0 && hw.Site.ID != siteID {
+			continue
+		}
+		if tag != "" && !hasTag(hw.Tags, tag) {
+			continue
+		}
+		if role != "" && hwRole(hw) != role {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(hw.ID))
+		items = append(items, map[string]interface{}{
+			"id":          hw.ID,
+			"name":        hw.Name,
+			"type":        hw.Type,
+			"tenantid":    hw.Tenant.ID,
+			"siteid":      hw.Site.ID,
+			"description": hw.Description,
+			"mainip":      hw.MainAddress,
+			"mgmtip":      hw.MgmtAddress,
+			"asnumber":    strconv.Itoa(hw.Asn),
+			"role":        hwRole(hw),
+			"tags":        hw.Tags,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/netrisai/netriswebapi/v1/types/inventoryprofile"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 
 	api "github.com/netrisai/netriswebapi/v2"
 )
@@ -31,10 +33,19 @@ func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data Source: inventory profiles",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of inventory profile",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of inventory profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of inventory profile",
 			},
 			"description": {
 				Computed:    true,
@@ -47,7 +58,7 @@ func DataResource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IPv4 subnets allowed to ssh.",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddressOrPrefix,
 					Type:         schema.TypeString,
 				},
 			},
@@ -56,7 +67,7 @@ func DataResource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IPv6 subnets allowed to ssh.",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddressOrPrefix,
 					Type:         schema.TypeString,
 				},
 			},
@@ -80,7 +91,7 @@ func DataResource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IP addresses of DNS servers.",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddress,
 					Type:         schema.TypeString,
 				},
 			},
@@ -91,7 +102,7 @@ func DataResource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"sourcesubnet": {
-							ValidateFunc: validateIPPrefix,
+							ValidateFunc: ipaddr.ValidateAddressOrPrefix,
 							Type:         schema.TypeString,
 							Required:     true,
 							Description:  "Source Subnet.",
@@ -229,7 +240,7 @@ func DataResource() *schema.Resource {
 							Type:        schema.TypeList,
 							Description: "List of IPv4 addresses/prefixes allowed to poll SNMPv2.",
 							Elem: &schema.Schema{
-								ValidateFunc: validateIPPrefix,
+								ValidateFunc: ipaddr.ValidateAddressOrPrefix,
 								Type:         schema.TypeString,
 							},
 						},
@@ -306,15 +317,13 @@ func DataResource() *schema.Resource {
 
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
-	var profile *inventoryprofile.Profile
-	var ok bool
-	profile, ok = findByName(d.Get("name").(string), clientset)
-	if !ok {
-		return fmt.Errorf("Coudn't find inventory profile '%s'", d.Get("name").(string))
+	profile, err := findProfile(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
 	}
 
 	d.SetId(strconv.Itoa(profile.ID))
-	err := d.Set("name", profile.Name)
+	err = d.Set("name", profile.Name)
 	if err != nil {
 		return err
 	}
@@ -434,6 +443,18 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func findProfile(clientset *api.Clientset, id, name string) (*inventoryprofile.Profile, error) {
+	list, err := clientset.InventoryProfile().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, id, name,
+		func(profile *inventoryprofile.Profile) int { return profile.ID },
+		func(profile *inventoryprofile.Profile) string { return profile.Name },
+		"inventory profile", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	return true, nil
 }
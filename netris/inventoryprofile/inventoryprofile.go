@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v1/types/inventoryprofile"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	api "github.com/netrisai/netriswebapi/v2"
 )
@@ -50,7 +51,8 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IPv4 subnets allowed to ssh. Example `[\"10.0.10.0/24\", \"172.16.16.16\"]`",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+					StateFunc:    ipaddr.StateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -59,7 +61,8 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IPv6 subnets allowed to ssh. Example `[\"2001:DB8::/32\"]`",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+					StateFunc:    ipaddr.StateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -75,6 +78,7 @@ func Resource() *schema.Resource {
 				Description: "List of domain names or IP addresses of NTP servers. Example `[\"0.pool.ntp.org\", \"132.163.96.5\"]`",
 				Elem: &schema.Schema{
 					ValidateFunc: validateNTP,
+					StateFunc:    ipaddr.StateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -83,7 +87,8 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IP addresses of DNS servers. Example `[\"1.1.1.1\", \"8.8.8.8\"]`",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddress,
+					StateFunc:    ipaddr.StateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -94,7 +99,8 @@ func Resource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"sourcesubnet": {
-							ValidateFunc: validateIPPrefix,
+							ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+							StateFunc:    ipaddr.StateFunc,
 							Type:         schema.TypeString,
 							Required:     true,
 							Description:  "Source Subnet. Example `10.0.0.0/8`",
@@ -254,7 +260,8 @@ func Resource() *schema.Resource {
 							Type:        schema.TypeList,
 							Description: "List of IPv4 addresses/prefixes allowed to poll SNMPv2.",
 							Elem: &schema.Schema{
-								ValidateFunc: validateIPPrefix,
+								ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+								StateFunc:    ipaddr.StateFunc,
 								Type:         schema.TypeString,
 							},
 						},
@@ -311,6 +318,7 @@ func Resource() *schema.Resource {
 							Description: "List of NetQ server addresses (IP addresses or domain names).",
 							Elem: &schema.Schema{
 								ValidateFunc: validateNTP,
+								StateFunc:    ipaddr.StateFunc,
 								Type:         schema.TypeString,
 							},
 						},
@@ -0,0 +1,190 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventorystatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Inventory Status",
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateType,
+				Description:  "Return only units of this type. Possible values: `switch`, `softgate`, `server`, `controller`.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only units of this site.",
+			},
+			"ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Return only units with these IDs. Example: `[netris_switch.spine1.id, netris_softgate.softgate1.id]`",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Status of every matching inventory unit.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The unit ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit name.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit type.",
+						},
+						"siteid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The site ID where this unit belongs.",
+						},
+						"heartbeat": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Heartbeat status of the unit's agent as reported by the controller.",
+						},
+						"agentversion": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the agent running on the unit. Empty until the agent has checked in.",
+						},
+						"nos": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Network operating system tag of the unit.",
+						},
+						"uptime": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Uptime of the unit as reported by the agent.",
+						},
+						"maintenance": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the unit is in maintenance mode.",
+						},
+						"lastseen": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the agent last checked in, in RFC 3339 format. Empty if it never has.",
+						},
+					},
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func validateType(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !(v == "switch" || v == "softgate" || v == "server" || v == "controller") {
+		errs = append(errs, fmt.Errorf("'%s' must be switch, softgate, server or controller, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+// formatLastSeen converts the controller's last-seen timestamp to RFC 3339.
+// The controller reports milliseconds, but older versions used seconds.
+func formatLastSeen(ts int) string {
+	if ts <= 0 {
+		return ""
+	}
+	t := time.UnixMilli(int64(ts))
+	if ts < 1e11 {
+		t = time.Unix(int64(ts), 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	hwType := d.Get("type").(string)
+	siteID := d.Get("siteid").(int)
+
+	wanted := make(map[int]bool)
+	for _, id := range d.Get("ids").(*schema.Set).List() {
+		wanted[id.(int)] = true
+	}
+
+	list, err := clientset.Inventory().Get()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, hw := range list {
+		if hwType != "" && hw.Type != hwType {
+			continue
+		}
+		if siteID > 0 && hw.Site.ID != siteID {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[hw.ID] {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(hw.ID))
+		items = append(items, map[string]interface{}{
+			"id":           hw.ID,
+			"name":         hw.Name,
+			"type":         hw.Type,
+			"siteid":       hw.Site.ID,
+			"heartbeat":    hw.Status,
+			"agentversion": hw.ConductorVersion,
+			"nos":          hw.Nos.Tag,
+			"uptime":       hw.Uptime,
+			"maintenance":  hw.MaintenanceMode,
+			"lastseen":     formatLastSeen(hw.LastSeen),
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
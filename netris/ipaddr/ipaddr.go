@@ -0,0 +1,181 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ipaddr validates IP addresses and prefixes in resource arguments
+// and brings them to the canonical form the controller returns, so that
+// `2001:DB8::1/64` and `2001:db8::1/64` don't show up as a diff.
+package ipaddr
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ParseAddr parses an IPv4 or IPv6 address. Zoned IPv6 addresses are
+// rejected, the controller has no use for them.
+func ParseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("zoned address %s is not supported", s)
+	}
+	return addr, nil
+}
+
+// ParsePrefix parses an address with prefix length, e.g. `10.0.1.1/24`.
+// Host bits are allowed and kept.
+func ParsePrefix(s string) (netip.Prefix, error) {
+	return netip.ParsePrefix(s)
+}
+
+// ParseAddrPort parses an address with port, e.g. `192.0.2.100:443` or
+// `[2001:db8::1]:443`. Zoned addresses and port 0 are rejected.
+func ParseAddrPort(s string) (netip.AddrPort, error) {
+	ap, err := netip.ParseAddrPort(s)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	if ap.Addr().Zone() != "" {
+		return netip.AddrPort{}, fmt.Errorf("zoned address %s is not supported", s)
+	}
+	if ap.Port() == 0 {
+		return netip.AddrPort{}, fmt.Errorf("port 0 is not supported: %s", s)
+	}
+	return ap, nil
+}
+
+// Canonical returns the canonical form of an address, prefix or address
+// with port: IPv6 in lower case with the longest run of zeros compressed.
+// Anything else, like `auto` or a host name, is returned unchanged.
+func Canonical(s string) string {
+	if p, err := ParsePrefix(s); err == nil {
+		return p.String()
+	}
+	if a, err := ParseAddr(s); err == nil {
+		return a.String()
+	}
+	if ap, err := ParseAddrPort(s); err == nil {
+		return ap.String()
+	}
+	return s
+}
+
+// StateFunc stores addresses and prefixes in canonical form.
+func StateFunc(val interface{}) string {
+	s, _ := val.(string)
+	return Canonical(s)
+}
+
+// DiffSuppress hides differences in the spelling of the same address or
+// prefix. Used where StateFunc can't be, such as map elements.
+func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return Canonical(old) == Canonical(new)
+}
+
+// HashString is a set function for sets of addresses or prefixes that hashes
+// them in canonical form.
+func HashString(v interface{}) int {
+	s, _ := v.(string)
+	return schema.HashString(Canonical(s))
+}
+
+// HashResource returns a set function for elements of the resource that
+// hashes the given keys in canonical form. Without it, a set element whose
+// address is spelled differently in the configuration than in the state gets
+// a different hash and is planned for replacement.
+func HashResource(resource *schema.Resource, keys ...string) schema.SchemaSetFunc {
+	hash := schema.HashResource(resource)
+	return func(v interface{}) int {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return hash(v)
+		}
+		c := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			c[k] = val
+		}
+		for _, k := range keys {
+			if s, ok := c[k].(string); ok {
+				c[k] = Canonical(s)
+			}
+		}
+		return hash(c)
+	}
+}
+
+// ValidateAddress accepts a single IPv4 or IPv6 address.
+func ValidateAddress(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, err := ParseAddr(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid %s: %s, expected an IP address", key, v))
+	}
+	return warns, errs
+}
+
+// ValidatePrefix accepts an address with prefix length, e.g. `10.0.1.1/24`.
+func ValidatePrefix(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, err := ParsePrefix(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid %s: %s, expected an IP prefix", key, v))
+	}
+	return warns, errs
+}
+
+// ValidateAddressOrPrefix accepts either an address or a prefix.
+func ValidateAddressOrPrefix(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, err := ParsePrefix(v); err == nil {
+		return warns, errs
+	}
+	if _, err := ParseAddr(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid %s: %s, expected an IP address or prefix", key, v))
+	}
+	return warns, errs
+}
+
+// ValidateAddrPort accepts an address with port, e.g. `192.0.2.100:443`.
+func ValidateAddrPort(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, err := ParseAddrPort(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid %s: %s, expected an IP address with port", key, v))
+	}
+	return warns, errs
+}
+
+// ValidateIPv4Prefix accepts an IPv4 address with prefix length.
+func ValidateIPv4Prefix(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	p, err := ParsePrefix(v)
+	if err != nil || !p.Addr().Is4() {
+		errs = append(errs, fmt.Errorf("'%s' must be an IPv4 address with prefix length, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+// ValidateIPv6Prefix accepts an IPv6 address with prefix length. IPv4-mapped
+// addresses are rejected.
+func ValidateIPv6Prefix(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	p, err := ParsePrefix(v)
+	if err != nil || !p.Addr().Is6() || p.Addr().Is4In6() {
+		errs = append(errs, fmt.Errorf("'%s' must be an IPv6 address with prefix length, got: %s", key, v))
+	}
+	return warns, errs
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipaddr
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestCanonical(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"10.0.1.1", "10.0.1.1"},
+		{"10.0.1.1/24", "10.0.1.1/24"},
+		{"2001:DB8::1", "2001:db8::1"},
+		{"2001:DB8::1/64", "2001:db8::1/64"},
+		{"2001:0db8:0000:0000:0000:0000:0000:0001/64", "2001:db8::1/64"},
+		{"2001:db8:0:0:1:0:0:1", "2001:db8::1:0:0:1"},
+		{"::/0", "::/0"},
+		{"auto", "auto"},
+		{"0.pool.ntp.org", "0.pool.ntp.org"},
+		{"", ""},
+		{"010.0.0.1", "010.0.0.1"},
+		{"192.0.2.1:443", "192.0.2.1:443"},
+		{"[2001:DB8:0::1]:443", "[2001:db8::1]:443"},
+		{"ntp.example.com:123", "ntp.example.com:123"},
+	}
+
+	for _, c := range cases {
+		if got := Canonical(c.in); got != c.want {
+			t.Errorf("Canonical(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestValidators(t *testing.T) {
+	cases := []struct {
+		in              string
+		address         bool
+		prefix          bool
+		addressOrPrefix bool
+		ipv4Prefix      bool
+		ipv6Prefix      bool
+	}{
+		{"192.0.2.1", true, false, true, false, false},
+		{"192.0.2.1/24", false, true, true, true, false},
+		{"0.0.0.0/0", false, true, true, true, false},
+		{"2001:DB8::1", true, false, true, false, false},
+		{"2001:db8::1/64", false, true, true, false, true},
+		{"::ffff:192.0.2.1/120", false, true, true, false, false},
+		{"192.0.2.1/33", false, false, false, false, false},
+		{"2001:db8::1/129", false, false, false, false, false},
+		{"192.0.2.256", false, false, false, false, false},
+		{"010.0.0.1", false, false, false, false, false},
+		{"192.0.2", false, false, false, false, false},
+		{"fe80::1%eth0", false, false, false, false, false},
+		{"192.0.2.1/24/24", false, false, false, false, false},
+		{" 192.0.2.1", false, false, false, false, false},
+		{"auto", false, false, false, false, false},
+		{"", false, false, false, false, false},
+	}
+
+	for _, c := range cases {
+		check := func(name string, fn schema.SchemaValidateFunc, want bool) {
+			_, errs := fn(c.in, "key")
+			if got := len(errs) == 0; got != want {
+				t.Errorf("%s(%q) valid = %t, want %t", name, c.in, got, want)
+			}
+		}
+		check("ValidateAddress", ValidateAddress, c.address)
+		check("ValidatePrefix", ValidatePrefix, c.prefix)
+		check("ValidateAddressOrPrefix", ValidateAddressOrPrefix, c.addressOrPrefix)
+		check("ValidateIPv4Prefix", ValidateIPv4Prefix, c.ipv4Prefix)
+		check("ValidateIPv6Prefix", ValidateIPv6Prefix, c.ipv6Prefix)
+	}
+}
+
+func TestValidateAddrPort(t *testing.T) {
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{"192.0.2.100:443", true},
+		{"192.0.2.100:65535", true},
+		{"[2001:db8::1]:443", true},
+		{"192.0.2.100:0", false},
+		{"192.0.2.100:65536", false},
+		{"192.0.2.100", false},
+		{"192.0.2.256:443", false},
+		{"2001:db8::1:443", false},
+		{"[fe80::1%eth0]:443", false},
+		{"host:443", false},
+	}
+
+	for _, c := range cases {
+		_, errs := ValidateAddrPort(c.in, "key")
+		if got := len(errs) == 0; got != c.want {
+			t.Errorf("ValidateAddrPort(%q) valid = %t, want %t", c.in, got, c.want)
+		}
+	}
+}
+
+func TestDiffSuppress(t *testing.T) {
+	cases := []struct {
+		old, new string
+		want     bool
+	}{
+		{"2001:db8::1", "2001:DB8::1", true},
+		{"2001:db8::1/64", "2001:DB8:0::1/64", true},
+		{"2001:db8::1/64", "2001:db8::1/48", false},
+		{"192.0.2.1", "192.0.2.2", false},
+		{"", "192.0.2.1", false},
+		{"2", "2", true},
+	}
+
+	for _, c := range cases {
+		if got := DiffSuppress("key", c.old, c.new, nil); got != c.want {
+			t.Errorf("DiffSuppress(%q, %q) = %t, want %t", c.old, c.new, got, c.want)
+		}
+	}
+}
+
+func TestHashString(t *testing.T) {
+	if HashString("[2001:DB8::1]:443") != HashString("[2001:db8::1]:443") {
+		t.Errorf("HashString differs for spellings of the same address with port")
+	}
+	if HashString("2001:DB8::/32") != HashString("2001:db8::/32") {
+		t.Errorf("HashString differs for spellings of the same prefix")
+	}
+	if HashString("192.0.2.1:443") == HashString("192.0.2.1:444") {
+		t.Errorf("HashString is the same for different ports")
+	}
+}
+
+func TestHashResource(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"prefix":  {Type: schema.TypeString, Required: true},
+			"comment": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	hash := HashResource(resource, "prefix")
+
+	cases := []struct {
+		a, b map[string]interface{}
+		same bool
+	}{
+		{
+			a:    map[string]interface{}{"prefix": "2001:DB8::1/64", "comment": "x"},
+			b:    map[string]interface{}{"prefix": "2001:db8::1/64", "comment": "x"},
+			same: true,
+		},
+		{
+			a:    map[string]interface{}{"prefix": "2001:db8::1/64", "comment": "x"},
+			b:    map[string]interface{}{"prefix": "2001:db8::2/64", "comment": "x"},
+			same: false,
+		},
+		{
+			a:    map[string]interface{}{"prefix": "192.0.2.1/24", "comment": "X"},
+			b:    map[string]interface{}{"prefix": "192.0.2.1/24", "comment": "x"},
+			same: false,
+		},
+	}
+
+	for _, c := range cases {
+		if got := hash(c.a) == hash(c.b); got != c.same {
+			t.Errorf("hash(%v) == hash(%v) is %t, want %t", c.a, c.b, got, c.same)
+		}
+	}
+
+	m := map[string]interface{}{"prefix": "2001:DB8::1/64"}
+	hash(m)
+	if m["prefix"] != "2001:DB8::1/64" {
+		t.Errorf("HashResource modified its input: %v", m)
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipreservation
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/netip"
+	"strconv"
+	"sync"
+
+	"github.com/netrisai/netriswebapi/http"
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/netriswebapi/v2/types/ipreservation"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+	"github.com/netrisai/terraform-provider-netris/netris/subnet"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// reserveMu serializes address selection so that reservations created in the
+// same apply never pick the same free address.
+var reserveMu sync.Mutex
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Creates and manages IP Reservations",
+		Schema: map[string]*schema.Schema{
+			"subnetid": {
+				ForceNew:    true,
+				Required:    true,
+				Type:        schema.TypeInt,
+				Description: "ID of the subnet to reserve the address in.",
+			},
+			"address": {
+				ForceNew:     true,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: ipaddr.ValidateAddress,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "The address to reserve. If not specified, the next free host address of the subnet is reserved.",
+			},
+			"description": {
+				ForceNew:    true,
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Reservation description. Example: `VIP for my-service`",
+			},
+			"vpcid": {
+				ForceNew:    true,
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "ID of VPC of the subnet. If not specified, the VPC marked as a default is used.",
+			},
+		},
+		Create: resourceCreate,
+		Read:   resourceRead,
+		Delete: resourceDelete,
+		Exists: resourceExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceImport,
+		},
+	}
+}
+
+// usedAddresses returns every address of the subnet that must not be handed
+// out, mapped to a human readable owner for error messages.
+func usedAddresses(clientset *api.Clientset, s *ipam.IPAM) (map[netip.Addr]string, error) {
+	used := make(map[netip.Addr]string)
+
+	if addr, ok := parseAddr(s.DefaultGateway); ok {
+		used[addr] = "the default gateway"
+	}
+
+	hosts, err := clientset.IPAM().GetHosts(s.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		if addr, ok := parseAddr(host.Address); ok {
+			used[addr] = fmt.Sprintf("%s '%s'", host.Type, host.Name)
+		}
+	}
+
+	reservations, err := clientset.IPReservation().Get()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reservations {
+		if r.Host.Subnet.ID != s.ID {
+			continue
+		}
+		if addr, ok := parseAddr(r.Host.Address); ok {
+			used[addr] = fmt.Sprintf("reservation '%d'", r.ID)
+		}
+	}
+
+	return used, nil
+}
+
+func resourceCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] ipreservation resourceCreate")
+	clientset := m.(*api.Clientset)
+
+	subnetID := d.Get("subnetid").(int)
+	vpcid := d.Get("vpcid").(int)
+
+	reserveMu.Lock()
+	defer reserveMu.Unlock()
+
+	var subnets []*ipam.IPAM
+	var err error
+	if vpcid > 0 {
+		subnets, err = clientset.IPAM().GetSubnetsByVPC(vpcid)
+	} else {
+		subnets, err = clientset.IPAM().GetSubnets()
+	}
+	if err != nil {
+		return err
+	}
+
+	s := subnet.GetByID(subnets, subnetID)
+	if s == nil {
+		return fmt.Errorf("subnet '%d' not found", subnetID)
+	}
+
+	prefix, err := netip.ParsePrefix(s.Prefix)
+	if err != nil {
+		return fmt.Errorf("subnet '%s' has invalid prefix: %s", s.Name, err)
+	}
+
+	used, err := usedAddresses(clientset, s)
+	if err != nil {
+		return err
+	}
+
+	var addr netip.Addr
+	if a := d.Get("address").(string); a != "" {
+		var ok bool
+		if addr, ok = parseAddr(a); !ok {
+			return fmt.Errorf("invalid address: %s", a)
+		}
+		if err := checkAddress(prefix, addr, used); err != nil {
+			return err
+		}
+	} else {
+		addr, err = nextFreeAddress(prefix, used)
+		if err != nil {
+			return err
+		}
+	}
+
+	reservation := &ipreservation.IPReservation{
+		Host: ipreservation.Host{
+			Address: addr.String(),
+			Subnet:  ipreservation.Subnet{ID: s.ID},
+		},
+		Meta: map[string]interface{}{
+			"description": d.Get("description").(string),
+		},
+	}
+
+	js, _ := json.Marshal(reservation)
+	log.Println("[DEBUG]", string(js))
+
+	reply, err := clientset.IPReservation().Add(reservation)
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	log.Println("[DEBUG]", string(reply.Data))
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", string(reply.Data))
+	}
+
+	idStruct := struct {
+		ID int `json:"id"`
+	}{}
+
+	data, err := reply.Parse()
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	err = http.Decode(data.Data, &idStruct)
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	log.Println("[DEBUG] ID:", idStruct.ID)
+
+	d.SetId(strconv.Itoa(idStruct.ID))
+	err = d.Set("address", addr.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func reservationExists(clientset *api.Clientset, id int) (bool, error) {
+	items, err := clientset.IPReservation().Get()
+	if err != nil {
+		return false, err
+	}
+	for _, item := range items {
+		if item.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func resourceRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] ipreservation resourceRead")
+	clientset := m.(*api.Clientset)
+
+	id, _ := strconv.Atoi(d.Id())
+	item, err := clientset.IPReservation().GetByID(id)
+	if err != nil {
+		// The client doesn't return the status code, so a failed lookup is
+		// only taken as a deletion when the reservation isn't listed either.
+		exists, listErr := reservationExists(clientset, id)
+		if listErr != nil || exists {
+			return err
+		}
+		item = nil
+	}
+	if item == nil || item.ID == 0 {
+		log.Printf("[WARN] ipreservation %d not found, removing it from the state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(strconv.Itoa(item.ID))
+	if addr, ok := parseAddr(item.Host.Address); ok {
+		err = d.Set("address", addr.String())
+		if err != nil {
+			return err
+		}
+	}
+	err = d.Set("subnetid", item.Host.Subnet.ID)
+	if err != nil {
+		return err
+	}
+	if description, ok := item.Meta["description"].(string); ok {
+		err = d.Set("description", description)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] ipreservation resourceDelete")
+	clientset := m.(*api.Clientset)
+
+	id, _ := strconv.Atoi(d.Id())
+	reply, err := clientset.IPReservation().Delete(id)
+	if err != nil {
+		return err
+	}
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", string(reply.Data))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	log.Println("[DEBUG] ipreservation resourceExists")
+	clientset := m.(*api.Clientset)
+
+	id, _ := strconv.Atoi(d.Id())
+	item, err := clientset.IPReservation().GetByID(id)
+	if err != nil {
+		log.Println("[DEBUG] ipreservation response err:", err)
+	}
+
+	if item == nil {
+		return false, nil
+	}
+	if item.ID > 0 {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func resourceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Println("[DEBUG] ipreservation resourceImport")
+	clientset := m.(*api.Clientset)
+
+	addr, ok := parseAddr(d.Id())
+	if !ok {
+		return []*schema.ResourceData{d}, fmt.Errorf("invalid address: %s", d.Id())
+	}
+
+	list, err := clientset.IPReservation().Get()
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+
+	for _, r := range list {
+		if a, ok := parseAddr(r.Host.Address); ok && a == addr {
+			d.SetId(strconv.Itoa(r.ID))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return []*schema.ResourceData{d}, fmt.Errorf("ip reservation '%s' not found", addr)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipreservation
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+)
+
+// parseAddr accepts both plain addresses and addresses in CIDR notation,
+// the way the controller reports gateways and hosts.
+func parseAddr(s string) (netip.Addr, bool) {
+	if p, err := ipaddr.ParsePrefix(s); err == nil {
+		return p.Addr(), true
+	}
+	addr, err := ipaddr.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// isUsable reports whether addr is a host address of the subnet, excluding
+// the network address and, for IPv4 subnets larger than /31, the broadcast.
+func isUsable(subnet netip.Prefix, addr netip.Addr) bool {
+	subnet = subnet.Masked()
+	if !subnet.Contains(addr) {
+		return false
+	}
+	bits := subnet.Addr().BitLen()
+	if subnet.Bits() >= bits-1 {
+		return true
+	}
+	if addr == subnet.Addr() {
+		return false
+	}
+	if addr.Is4() && !subnet.Contains(addr.Next()) {
+		return false
+	}
+	return true
+}
+
+func checkAddress(subnet netip.Prefix, addr netip.Addr, used map[netip.Addr]string) error {
+	if !isUsable(subnet, addr) {
+		return fmt.Errorf("address %s is not a usable host address of subnet %s", addr, subnet)
+	}
+	if owner, ok := used[addr]; ok {
+		return fmt.Errorf("address %s is already used by %s", addr, owner)
+	}
+	return nil
+}
+
+func nextFreeAddress(subnet netip.Prefix, used map[netip.Addr]string) (netip.Addr, error) {
+	subnet = subnet.Masked()
+	for addr := subnet.Addr(); addr.IsValid() && subnet.Contains(addr); addr = addr.Next() {
+		if !isUsable(subnet, addr) {
+			continue
+		}
+		if _, ok := used[addr]; !ok {
+			return addr, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("no free address left in subnet %s", subnet)
+}
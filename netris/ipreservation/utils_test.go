@@ -0,0 +1,175 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipreservation
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseAddr(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"192.0.2.1", "192.0.2.1", true},
+		{"192.0.2.1/24", "192.0.2.1", true},
+		{"2001:DB8::1/64", "2001:db8::1", true},
+		{"fe80::1%eth0", "", false},
+		{"192.0.2.256", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := parseAddr(c.in)
+		if ok != c.ok || (ok && got.String() != c.want) {
+			t.Errorf("parseAddr(%q) = %s, %t, want %s, %t", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestIsUsable(t *testing.T) {
+	cases := []struct {
+		subnet string
+		addr   string
+		want   bool
+	}{
+		{"192.0.2.0/24", "192.0.2.0", false},
+		{"192.0.2.0/24", "192.0.2.1", true},
+		{"192.0.2.0/24", "192.0.2.254", true},
+		{"192.0.2.0/24", "192.0.2.255", false},
+		{"192.0.2.0/24", "192.0.3.1", false},
+		{"192.0.2.77/24", "192.0.2.0", false},
+		{"192.0.2.0/31", "192.0.2.0", true},
+		{"192.0.2.0/31", "192.0.2.1", true},
+		{"192.0.2.1/32", "192.0.2.1", true},
+		{"2001:db8::/64", "2001:db8::", false},
+		{"2001:db8::/64", "2001:db8::1", true},
+		{"2001:db8::/64", "2001:db8::ffff:ffff:ffff:ffff", true},
+		{"2001:db8::/127", "2001:db8::", true},
+		{"2001:db8::/64", "192.0.2.1", false},
+	}
+
+	for _, c := range cases {
+		got := isUsable(netip.MustParsePrefix(c.subnet), netip.MustParseAddr(c.addr))
+		if got != c.want {
+			t.Errorf("isUsable(%s, %s) = %t, want %t", c.subnet, c.addr, got, c.want)
+		}
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	subnet := netip.MustParsePrefix("192.0.2.0/24")
+	used := map[netip.Addr]string{netip.MustParseAddr("192.0.2.1"): "gateway"}
+
+	cases := []struct {
+		addr string
+		err  bool
+	}{
+		{"192.0.2.2", false},
+		{"192.0.2.1", true},
+		{"192.0.2.0", true},
+		{"192.0.2.255", true},
+		{"198.51.100.1", true},
+	}
+
+	for _, c := range cases {
+		err := checkAddress(subnet, netip.MustParseAddr(c.addr), used)
+		if (err != nil) != c.err {
+			t.Errorf("checkAddress(%s) error = %v, want error %t", c.addr, err, c.err)
+		}
+	}
+}
+
+func TestNextFreeAddress(t *testing.T) {
+	cases := []struct {
+		name   string
+		subnet string
+		used   []string
+		want   string
+		err    bool
+	}{
+		{
+			name:   "skips the network address",
+			subnet: "192.0.2.0/24",
+			want:   "192.0.2.1",
+		},
+		{
+			name:   "skips the gateway and used hosts",
+			subnet: "192.0.2.0/24",
+			used:   []string{"192.0.2.1", "192.0.2.2"},
+			want:   "192.0.2.3",
+		},
+		{
+			name:   "fills a gap",
+			subnet: "192.0.2.0/29",
+			used:   []string{"192.0.2.1", "192.0.2.3"},
+			want:   "192.0.2.2",
+		},
+		{
+			name:   "full subnet",
+			subnet: "192.0.2.0/29",
+			used:   []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4", "192.0.2.5", "192.0.2.6"},
+			err:    true,
+		},
+		{
+			name:   "/31 uses both addresses",
+			subnet: "192.0.2.0/31",
+			used:   []string{"192.0.2.0"},
+			want:   "192.0.2.1",
+		},
+		{
+			name:   "/32",
+			subnet: "192.0.2.1/32",
+			want:   "192.0.2.1",
+		},
+		{
+			name:   "full /32",
+			subnet: "192.0.2.1/32",
+			used:   []string{"192.0.2.1"},
+			err:    true,
+		},
+		{
+			name:   "IPv6",
+			subnet: "2001:db8::/64",
+			used:   []string{"2001:db8::1"},
+			want:   "2001:db8::2",
+		},
+	}
+
+	for _, c := range cases {
+		used := map[netip.Addr]string{}
+		for _, u := range c.used {
+			used[netip.MustParseAddr(u)] = "host"
+		}
+		got, err := nextFreeAddress(netip.MustParsePrefix(c.subnet), used)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected error, got %s", c.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if got.String() != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4lb
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/l4lb"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: L4LBs",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the L4LB.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the L4LB.",
+			},
+			"tenantid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of tenant. Users of this tenant are permitted to edit this unit.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The site ID of the L4LB.",
+			},
+			"vpcid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of VPC.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Administrative status. `active` or `disable`.",
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Protocol. `tcp` or `udp`.",
+			},
+			"frontend": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "L4LB frontend IP, including an automatically assigned one.",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "L4LB frontend port.",
+			},
+			"check": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Health check in the same format as the `check` block of the `netris_l4lb` resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"requestpath": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"health": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Overall L4LB health status as reported by the controller.",
+			},
+			"healthmessage": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Human readable description of the overall health status.",
+			},
+			"backends": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Backends with their health check results.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Backend in `ip`:`port` format, as used by the `backend` attribute of the `netris_l4lb` resource.",
+						},
+						"ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Backend IP address.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Backend port.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Backend health status as reported by the controller.",
+						},
+						"response": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Last health check response of the backend.",
+						},
+						"maintenance": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the backend is in maintenance mode.",
+						},
+					},
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	lb, err := findL4LB(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(lb.ID))
+	err = d.Set("name", lb.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("tenantid", lb.Tenant.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("siteid", lb.Site.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("vpcid", lb.Vpc.ID)
+	if err != nil {
+		return err
+	}
+	state := "disable"
+	if lb.Status == "enable" {
+		state = "active"
+	}
+	err = d.Set("state", state)
+	if err != nil {
+		return err
+	}
+	err = d.Set("protocol", strings.ToLower(lb.Protocol))
+	if err != nil {
+		return err
+	}
+	err = d.Set("frontend", lb.IP)
+	if err != nil {
+		return err
+	}
+	err = d.Set("port", lb.Port)
+	if err != nil {
+		return err
+	}
+	err = d.Set("check", flattenCheck(lb.HealthCheck))
+	if err != nil {
+		return err
+	}
+	err = d.Set("health", lb.Label.Status)
+	if err != nil {
+		return err
+	}
+	err = d.Set("healthmessage", lb.Label.Text)
+	if err != nil {
+		return err
+	}
+
+	backends := make([]map[string]interface{}, 0)
+	for _, b := range lb.BackendIPs {
+		port, _ := strconv.Atoi(b.Port)
+		backends = append(backends, map[string]interface{}{
+			"address":     net.JoinHostPort(b.IP, b.Port),
+			"ip":          b.IP,
+			"port":        port,
+			"status":      b.Status,
+			"response":    b.Response,
+			"maintenance": b.Maintenance,
+		})
+	}
+	err = d.Set("backends", backends)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func findL4LB(clientset *api.Clientset, id, name string) (*l4lb.LoadBalancer, error) {
+	if id != "" {
+		lbID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid l4lb id '%s'", id)
+		}
+		lb, err := clientset.L4LB().GetByID(lbID)
+		if err != nil || lb == nil || lb.ID == 0 {
+			return nil, fmt.Errorf("couldn't find l4lb with id '%s'", id)
+		}
+		return lb, nil
+	}
+
+	list, err := clientset.L4LB().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, "", name,
+		func(lb *l4lb.LoadBalancer) int { return lb.ID },
+		func(lb *l4lb.LoadBalancer) string { return lb.Name },
+		"l4lb", "id")
+}
@@ -20,12 +20,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"regexp"
+	"net"
 	"strconv"
 	"strings"
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/l4lb"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	api "github.com/netrisai/netriswebapi/v2"
 
@@ -66,10 +67,12 @@ func Resource() *schema.Resource {
 				Description: "Protocol. Possible values: `tcp` or `udp`",
 			},
 			"frontend": {
-				Optional:    true,
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "L4LB frontend IP. If not specified, will be assigned automatically from subnets with relevant purpose.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: ipaddr.ValidateAddress,
+				StateFunc:    ipaddr.StateFunc,
+				Computed:     true,
+				Description:  "L4LB frontend IP. If not specified, will be assigned automatically from subnets with relevant purpose.",
 			},
 			"port": {
 				Optional:    true,
@@ -79,18 +82,42 @@ func Resource() *schema.Resource {
 			"backend": {
 				Optional: true,
 				Type:     schema.TypeSet,
+				Set:      ipaddr.HashString,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: ipaddr.ValidateAddrPort,
+					StateFunc:    ipaddr.StateFunc,
 				},
 				Description: "List of backends. Valid value is `ip`:`port` Example `[\"192.0.2.100:443\", \"192.0.2.101:443\"]`",
 			},
 			"check": {
 				Optional:    true,
-				Type:        schema.TypeMap,
+				Computed:    true,
+				Type:        schema.TypeList,
+				MaxItems:    1,
 				Description: "A health check determines whether instances in the target pool are healthy. If protocol == `udp` then check.type should be `none`",
-				Elem: &schema.Schema{
-					Type:     schema.TypeString,
-					Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Optional:     true,
+							Default:      "tcp",
+							ValidateFunc: validateCheckType,
+							Type:         schema.TypeString,
+							Description:  "Health check type. Possible values: `tcp`, `http` or `none`. Default value is `tcp`",
+						},
+						"timeout": {
+							Optional:     true,
+							Default:      2000,
+							ValidateFunc: validateCheckTimeout,
+							Type:         schema.TypeInt,
+							Description:  "Health check timeout in milliseconds. Ignored when type == `none`. Default value is `2000`",
+						},
+						"requestpath": {
+							Optional:    true,
+							Type:        schema.TypeString,
+							Description: "Request path of the health check. Only when type == `http`. Example: `/health`",
+						},
+					},
 				},
 			},
 			"vpcid": {
@@ -108,7 +135,25 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceImport,
 		},
+		CustomizeDiff: customizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeCheckV0,
+			},
+		},
+	}
+}
+
+// customizeDiff rejects health check settings the controller would ignore or
+// refuse, see validateCheck.
+func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("protocol") || !d.NewValueKnown("check") {
+		return nil
 	}
+	return validateCheck(d.Get("protocol").(string), d.Get("check").([]interface{}))
 }
 
 func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
@@ -118,14 +163,11 @@ func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 func resourceCreate(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	bReg := regexp.MustCompile(`^(?P<ip>(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])):(?P<port>([1-9]|[1-9][0-9]{1,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-4]))$`)
-
 	tenantID := d.Get("tenantid").(int)
 	siteID := d.Get("siteid").(int)
 	vpcid := d.Get("vpcid").(int)
 
 	var state string
-	var timeout string
 	proto := "TCP"
 
 	lbBackends := []l4lb.LBAddBackend{}
@@ -134,17 +176,14 @@ func resourceCreate(d *schema.ResourceData, m interface{}) error {
 	ipForTenant := ""
 
 	for _, b := range l4lbMetaBackends {
-		backend := b.(string)
-		valueMatch := bReg.FindStringSubmatch(string(backend))
-		result := regParser(valueMatch, bReg.SubexpNames())
-		port, err := strconv.Atoi(result["port"])
+		backend, err := ipaddr.ParseAddrPort(b.(string))
 		if err != nil {
 			return err
 		}
-		ipForTenant = result["ip"]
+		ipForTenant = backend.Addr().String()
 		lbBackends = append(lbBackends, l4lb.LBAddBackend{
-			IP:   result["ip"],
-			Port: port,
+			IP:   backend.Addr().String(),
+			Port: int(backend.Port()),
 		})
 	}
 
@@ -176,46 +215,7 @@ func resourceCreate(d *schema.ResourceData, m interface{}) error {
 		proto = protocol
 	}
 
-	checkType := ""
-	checkTimeout := 0
-	checkRequestPath := ""
-
-	check := d.Get("check").(map[string]interface{})
-	if v, ok := check["type"]; ok {
-		checkType = strings.ToLower(v.(string))
-	}
-	if v, ok := check["timeout"]; ok {
-		checkTimeout, _ = strconv.Atoi(v.(string))
-	}
-	if v, ok := check["requestPath"]; ok {
-		checkRequestPath = v.(string)
-	}
-
-	healthCheck := ""
-	requestPath := ""
-
-	if proto == "TCP" {
-		switch checkType {
-		case "", "tcp":
-			healthCheck = "TCP"
-		case "http":
-			healthCheck = "HTTP"
-			requestPath = checkRequestPath
-		case "none":
-			healthCheck = "None"
-		default:
-			healthCheck = "HTTP"
-			requestPath = checkRequestPath
-		}
-
-		if healthCheck == "TCP" || healthCheck == "HTTP" {
-			if checkTimeout == 0 {
-				timeout = "2000"
-			} else {
-				timeout = strconv.Itoa(checkTimeout)
-			}
-		}
-	}
+	healthCheck, timeout, requestPath := expandCheck(proto, d.Get("check").([]interface{}))
 
 	automatic := false
 	frontendIP := d.Get("frontend").(string)
@@ -346,28 +346,14 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	check := make(map[string]interface{})
-	lbCheckType := "None"
-	lbCheckTimeout := ""
-	if l4lb.HealthCheck.HTTP.Timeout != "" {
-		lbCheckType = "http"
-		check["requestPath"] = l4lb.HealthCheck.HTTP.RequestPath
-		lbCheckTimeout = l4lb.HealthCheck.HTTP.Timeout
-	}
-	if l4lb.HealthCheck.TCP.Timeout != "" {
-		lbCheckType = "tcp"
-		lbCheckTimeout = l4lb.HealthCheck.TCP.Timeout
-	}
-	check["type"] = lbCheckType
-	check["timeout"] = lbCheckTimeout
-	err = d.Set("check", check)
+	err = d.Set("check", flattenCheck(l4lb.HealthCheck))
 	if err != nil {
 		return err
 	}
 
 	backends := []interface{}{}
 	for _, b := range l4lb.BackendIPs {
-		backends = append(backends, fmt.Sprintf("%s:%s", b.IP, b.Port))
+		backends = append(backends, net.JoinHostPort(b.IP, b.Port))
 	}
 	err = d.Set("backend", backends)
 	if err != nil {
@@ -380,24 +366,22 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	bReg := regexp.MustCompile(`^(?P<ip>(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])):(?P<port>([1-9]|[1-9][0-9]{1,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-4]))$`)
-
 	var (
-		state   string
-		timeout string
-		proto   string = "tcp"
+		state string
+		proto string = "tcp"
 	)
 
 	lbBackends := []l4lb.LBBackend{}
 
 	l4lbMetaBackends := d.Get("backend").(*schema.Set).List()
 	for _, b := range l4lbMetaBackends {
-		backend := b.(string)
-		valueMatch := bReg.FindStringSubmatch(string(backend))
-		result := regParser(valueMatch, bReg.SubexpNames())
+		backend, err := ipaddr.ParseAddrPort(b.(string))
+		if err != nil {
+			return err
+		}
 		lbBackends = append(lbBackends, l4lb.LBBackend{
-			IP:   result["ip"],
-			Port: result["port"],
+			IP:   backend.Addr().String(),
+			Port: strconv.Itoa(int(backend.Port())),
 		})
 	}
 
@@ -413,46 +397,7 @@ func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 		proto = protocol
 	}
 
-	checkType := ""
-	checkTimeout := 0
-	checkRequestPath := ""
-
-	check := d.Get("check").(map[string]interface{})
-	if v, ok := check["type"]; ok {
-		checkType = strings.ToLower(v.(string))
-	}
-	if v, ok := check["timeout"]; ok {
-		checkTimeout, _ = strconv.Atoi(v.(string))
-	}
-	if v, ok := check["requestPath"]; ok {
-		checkRequestPath = v.(string)
-	}
-
-	healthCheck := ""
-	requestPath := ""
-
-	if strings.ToUpper(proto) == "TCP" {
-		switch checkType {
-		case "", "tcp":
-			healthCheck = "TCP"
-		case "http":
-			healthCheck = "HTTP"
-			requestPath = checkRequestPath
-		case "none":
-			healthCheck = "None"
-		default:
-			healthCheck = "HTTP"
-			requestPath = checkRequestPath
-		}
-
-		if healthCheck == "TCP" || healthCheck == "HTTP" {
-			if checkTimeout == 0 {
-				timeout = "2000"
-			} else {
-				timeout = strconv.Itoa(checkTimeout)
-			}
-		}
-	}
+	healthCheck, timeout, requestPath := expandCheck(proto, d.Get("check").([]interface{}))
 
 	automatic := false
 	frontendIP := d.Get("frontend").(string)
@@ -0,0 +1,115 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4lb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// resourceV0 is the schema before check became a block. It was a map of
+// strings with the keys type, timeout and requestPath.
+func resourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tenantid": {
+				Optional: true,
+				Type:     schema.TypeInt,
+				ForceNew: true,
+			},
+			"siteid": {
+				Optional: true,
+				Type:     schema.TypeInt,
+				ForceNew: true,
+			},
+			"state": {
+				Optional: true,
+				Default:  "active",
+				Type:     schema.TypeString,
+			},
+			"protocol": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"frontend": {
+				Optional: true,
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"port": {
+				Optional: true,
+				Type:     schema.TypeInt,
+			},
+			"backend": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"check": {
+				Optional: true,
+				Type:     schema.TypeMap,
+				Elem: &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+			"vpcid": {
+				Optional: true,
+				Computed: true,
+				Type:     schema.TypeInt,
+			},
+		},
+	}
+}
+
+// upgradeCheckV0 turns the check map into a single check block. An empty map
+// is dropped, the block is computed and filled in by the next refresh.
+func upgradeCheckV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	old, _ := rawState["check"].(map[string]interface{})
+	if len(old) == 0 {
+		delete(rawState, "check")
+		return rawState, nil
+	}
+
+	check := map[string]interface{}{
+		"type":        "tcp",
+		"timeout":     2000,
+		"requestpath": "",
+	}
+	if v, ok := old["type"].(string); ok && v != "" {
+		check["type"] = strings.ToLower(v)
+	}
+	if v, ok := old["timeout"].(string); ok {
+		if timeout, err := strconv.Atoi(v); err == nil && timeout > 0 {
+			check["timeout"] = timeout
+		}
+	}
+	if v, ok := old["requestPath"].(string); ok {
+		check["requestpath"] = v
+	}
+
+	rawState["check"] = []interface{}{check}
+	return rawState, nil
+}
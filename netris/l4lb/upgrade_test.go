@@ -0,0 +1,101 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package l4lb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testResourceL4LBStateDataV0(check map[string]interface{}) map[string]interface{} {
+	state := map[string]interface{}{
+		"name":     "my-l4lb",
+		"protocol": "tcp",
+		"port":     8443,
+	}
+	if check != nil {
+		state["check"] = check
+	}
+	return state
+}
+
+func testResourceL4LBStateDataV1(check map[string]interface{}) map[string]interface{} {
+	state := map[string]interface{}{
+		"name":     "my-l4lb",
+		"protocol": "tcp",
+		"port":     8443,
+	}
+	if check != nil {
+		state["check"] = []interface{}{check}
+	}
+	return state
+}
+
+func TestResourceL4LBStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name string
+		v0   map[string]interface{}
+		v1   map[string]interface{}
+	}{
+		{
+			name: "no check",
+			v0:   nil,
+			v1:   nil,
+		},
+		{
+			name: "empty map",
+			v0:   map[string]interface{}{},
+			v1:   nil,
+		},
+		{
+			name: "none from flattenCheck",
+			v0:   map[string]interface{}{"type": "None", "timeout": ""},
+			v1:   map[string]interface{}{"type": "none", "timeout": 2000, "requestpath": ""},
+		},
+		{
+			name: "tcp",
+			v0:   map[string]interface{}{"type": "tcp", "timeout": "3000"},
+			v1:   map[string]interface{}{"type": "tcp", "timeout": 3000, "requestpath": ""},
+		},
+		{
+			name: "http with camelCase requestPath",
+			v0:   map[string]interface{}{"type": "http", "timeout": "3000", "requestPath": "/health"},
+			v1:   map[string]interface{}{"type": "http", "timeout": 3000, "requestpath": "/health"},
+		},
+		{
+			name: "non-numeric timeout",
+			v0:   map[string]interface{}{"type": "tcp", "timeout": "3s"},
+			v1:   map[string]interface{}{"type": "tcp", "timeout": 2000, "requestpath": ""},
+		},
+		{
+			name: "missing type",
+			v0:   map[string]interface{}{"timeout": "1000"},
+			v1:   map[string]interface{}{"type": "tcp", "timeout": 1000, "requestpath": ""},
+		},
+	}
+
+	for _, c := range cases {
+		expected := testResourceL4LBStateDataV1(c.v1)
+		actual, err := upgradeCheckV0(testResourceL4LBStateDataV0(c.v0), nil)
+		if err != nil {
+			t.Fatalf("%s: error migrating state: %s", c.name, err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s:\nexpected: %#v\ngot:      %#v", c.name, expected, actual)
+		}
+	}
+}
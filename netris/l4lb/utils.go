@@ -16,12 +16,67 @@ limitations under the License.
 
 package l4lb
 
-func regParser(valueMatch []string, subexpNames []string) map[string]string {
-	result := make(map[string]string)
-	for i, name := range subexpNames {
-		if i != 0 && name != "" {
-			result[name] = valueMatch[i]
-		}
-	}
-	return result
+import (
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v2/types/l4lb"
+)
+
+// expandCheck converts the check block to the health check, timeout and
+// request path of the API. Only TCP load balancers have a health check.
+func expandCheck(proto string, check []interface{}) (healthCheck, timeout, requestPath string) {
+	if strings.ToUpper(proto) != "TCP" {
+		return "", "", ""
+	}
+
+	checkType := "tcp"
+	checkTimeout := 0
+	checkRequestPath := ""
+	if len(check) > 0 && check[0] != nil {
+		c := check[0].(map[string]interface{})
+		checkType = c["type"].(string)
+		checkTimeout = c["timeout"].(int)
+		checkRequestPath = c["requestpath"].(string)
+	}
+
+	switch checkType {
+	case "http":
+		healthCheck = "HTTP"
+		requestPath = checkRequestPath
+	case "none":
+		return "None", "", ""
+	default:
+		healthCheck = "TCP"
+	}
+
+	if checkTimeout == 0 {
+		timeout = "2000"
+	} else {
+		timeout = strconv.Itoa(checkTimeout)
+	}
+
+	return healthCheck, timeout, requestPath
+}
+
+func flattenCheck(hc l4lb.LBHealthCheck) []interface{} {
+	check := map[string]interface{}{
+		"type":        "none",
+		"timeout":     2000,
+		"requestpath": "",
+	}
+	lbCheckTimeout := ""
+	if hc.HTTP.Timeout != "" {
+		check["type"] = "http"
+		check["requestpath"] = hc.HTTP.RequestPath
+		lbCheckTimeout = hc.HTTP.Timeout
+	}
+	if hc.TCP.Timeout != "" {
+		check["type"] = "tcp"
+		lbCheckTimeout = hc.TCP.Timeout
+	}
+	if timeout, err := strconv.Atoi(lbCheckTimeout); err == nil {
+		check["timeout"] = timeout
+	}
+	return []interface{}{check}
 }
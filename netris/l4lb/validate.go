@@ -19,6 +19,7 @@ package l4lb
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/ipam"
@@ -32,6 +33,41 @@ func validateState(val interface{}, key string) (warns []string, errs []error) {
 	return warns, errs
 }
 
+func validateCheckType(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !(v == "tcp" || v == "http" || v == "none") {
+		errs = append(errs, fmt.Errorf("'%s' must be tcp, http or none, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+func validateCheckTimeout(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(int)
+	if v <= 0 {
+		errs = append(errs, fmt.Errorf("'%s' must be a positive number of milliseconds, got: %d", key, v))
+	}
+	return warns, errs
+}
+
+// validateCheck checks the health check against the protocol. UDP load
+// balancers have no health check, and only HTTP checks have a request path.
+func validateCheck(protocol string, check []interface{}) error {
+	if len(check) == 0 || check[0] == nil {
+		return nil
+	}
+	c := check[0].(map[string]interface{})
+	checkType := c["type"].(string)
+
+	if strings.ToLower(protocol) == "udp" && checkType != "none" {
+		return fmt.Errorf("check.type must be none when protocol is udp, got: %s", checkType)
+	}
+	if c["requestpath"].(string) != "" && checkType != "http" {
+		return fmt.Errorf("check.requestpath is only supported when check.type is http, got type: %s", checkType)
+	}
+
+	return nil
+}
+
 func findTenantByIP(c *api.Clientset, ip string) (int, error) {
 	tenantID := 0
 	subnets, err := c.IPAM().Get()
@@ -19,9 +19,9 @@ package lag
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/netrisai/netriswebapi/v2/types/port"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -31,10 +31,19 @@ func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages Switch Ports",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the aggregated port",
+			},
 			"name": {
 				Type:         schema.TypeString,
 				ValidateFunc: validateName,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 				Description:  "Aggregated port name (agg1@switch1)",
 			},
 			"description": {
@@ -105,29 +114,21 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	name := d.Get("name").(string)
-
-	ports, err := clientset.Port().Get()
+	portID, err := findLAG(clientset, d.Get("id").(string), d.Get("name").(string))
 	if err != nil {
 		return err
 	}
 
-	var hwPort *port.Port
-	splitedName := strings.Split(name, "@")
-	aggPort := splitedName[0]
-	swName := splitedName[1]
-
-	for _, p := range ports {
-		if p.Info.Port == aggPort && p.SwitchName == swName {
-			hwPort, err = clientset.Port().GetByID(p.ID)
-			if err != nil {
-				return err
-			}
-			break
-		}
+	hwPort, err := clientset.Port().GetByID(portID)
+	if err != nil {
+		return err
 	}
 
 	d.SetId(strconv.Itoa(hwPort.ID))
+	err = d.Set("name", hwPort.Info.Port+"@"+hwPort.SwitchName)
+	if err != nil {
+		return err
+	}
 	err = d.Set("description", hwPort.Description)
 	if err != nil {
 		return err
@@ -184,6 +185,25 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// findLAG returns the ID of the aggregated port with the given ID or
+// `agg@switch` name.
+func findLAG(clientset *api.Clientset, id, name string) (int, error) {
+	ports, err := clientset.Port().Get()
+	if err != nil {
+		return 0, err
+	}
+
+	found, err := lookup.Find(ports, id, name,
+		func(p *port.Port) int { return p.ID },
+		func(p *port.Port) string { return p.Info.Port + "@" + p.SwitchName },
+		"lag", "id")
+	if err != nil {
+		return 0, err
+	}
+
+	return found.ID, nil
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	return true, nil
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/port"
+	"github.com/netrisai/terraform-provider-netris/netris/vlancheck"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -99,14 +100,45 @@ func Resource() *schema.Resource {
 				Description: "Each MC-LAG requires an ID value in the range of `1-65535`, unique for the given switch-pair",
 			},
 		},
-		Create: resourceCreate,
-		Read:   resourceRead,
-		Update: resourceUpdate,
-		Delete: resourceDelete,
-		Exists: resourceExists,
+		Create:        resourceCreate,
+		Read:          resourceRead,
+		Update:        resourceUpdate,
+		Delete:        resourceDelete,
+		Exists:        resourceExists,
+		CustomizeDiff: customizeDiff,
 	}
 }
 
+// customizeDiff checks the VLAN range of a new extension against the site and
+// the VLANs already in use on the member ports. Existing extensions are left
+// alone.
+func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("extension") || !d.NewValueKnown("extension") || !d.NewValueKnown("members") {
+		return nil
+	}
+	ext := d.Get("extension").(map[string]interface{})
+	vlanrange, ok := ext["vlanrange"].(string)
+	if !ok || vlanrange == "" {
+		return nil
+	}
+
+	clientset := m.(*api.Clientset)
+	name, _ := ext["extensionname"].(string)
+	if _, ok := findExtensionByName(name, clientset); ok {
+		return nil
+	}
+
+	checker := vlancheck.New(clientset)
+	for _, member := range d.Get("members").(*schema.Set).List() {
+		err := checker.Extension(vlanrange, 0, member.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 	return true
 }
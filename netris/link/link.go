@@ -26,12 +26,13 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/link"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 func Resource() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Description: "Creates and manages Links",
 		Schema: map[string]*schema.Schema{
 			"ports": {
@@ -47,7 +48,8 @@ func Resource() *schema.Resource {
 				Optional: true,
 				Type:     schema.TypeList,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:      schema.TypeString,
+					StateFunc: ipaddr.StateFunc,
 				},
 				Description: "List of two IPv4 addresses.",
 			},
@@ -55,7 +57,8 @@ func Resource() *schema.Resource {
 				Optional: true,
 				Type:     schema.TypeList,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:      schema.TypeString,
+					StateFunc: ipaddr.StateFunc,
 				},
 				Description: "List of two IPv6 addresses",
 			},
@@ -74,7 +77,8 @@ func Resource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"sharedipv4addr": {
-							ValidateFunc: validateIP,
+							ValidateFunc: ipaddr.ValidateAddress,
+							StateFunc:    ipaddr.StateFunc,
 							Type:         schema.TypeString,
 							Required:     true,
 							ForceNew:     true,
@@ -100,6 +104,12 @@ func Resource() *schema.Resource {
 			State: resourceImport,
 		},
 	}
+
+	// Hash addresses in canonical form, so that spelling them differently
+	// than the controller doesn't replace the set element.
+	mclag := r.Schema["mclag"]
+	mclag.Set = ipaddr.HashResource(mclag.Elem.(*schema.Resource), "sharedipv4addr")
+	return r
 }
 
 func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
@@ -342,19 +352,6 @@ func resourceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceDa
 	return []*schema.ResourceData{d}, nil
 }
 
-func validateIP(val interface{}, key string) (warns []string, errs []error) {
-	v := val.(string)
-	if !validateIPAddr(v) {
-		errs = append(errs, fmt.Errorf("invalid %s: %s", key, v))
-	}
-	return warns, errs
-}
-
-func validateIPAddr(s string) bool {
-	re := regexp.MustCompile(`(^(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)(\/([0-9]|[12]\d|3[0-2]))?$)|(^((([0-9A-Fa-f]{1,4}:){7}([0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){6}(:[0-9A-Fa-f]{1,4}|((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(([0-9A-Fa-f]{1,4}:){5}(((:[0-9A-Fa-f]{1,4}){1,2})|:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(([0-9A-Fa-f]{1,4}:){4}(((:[0-9A-Fa-f]{1,4}){1,3})|((:[0-9A-Fa-f]{1,4})?:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){3}(((:[0-9A-Fa-f]{1,4}){1,4})|((:[0-9A-Fa-f]{1,4}){0,2}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){2}(((:[0-9A-Fa-f]{1,4}){1,5})|((:[0-9A-Fa-f]{1,4}){0,3}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){1}(((:[0-9A-Fa-f]{1,4}){1,6})|((:[0-9A-Fa-f]{1,4}){0,4}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(:(((:[0-9A-Fa-f]{1,4}){1,7})|((:[0-9A-Fa-f]{1,4}){0,5}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:)))(%.+)?(\/([0-9]|[1-5][0-9]|6[0-4]))?$)`)
-	return re.Match([]byte(s))
-}
-
 // validateMAC validates a MAC address format
 func validateMAC(val interface{}, key string) (warns []string, errs []error) {
 	// Convert the input value to a string
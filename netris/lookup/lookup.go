@@ -0,0 +1,55 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lookup finds the object a data source refers to by ID or by name.
+package lookup
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Find returns the element of list with the given id or, when id is empty,
+// the only element with the given name. kind names the object in errors,
+// e.g. `vpc`, and hint says how to tell objects with the same name apart,
+// e.g. `id` or `id or vpcid`.
+func Find[T any](list []T, id, name string, idOf func(T) int, nameOf func(T) string, kind, hint string) (T, error) {
+	var zero, found T
+	ok := false
+	for _, item := range list {
+		if id != "" {
+			if strconv.Itoa(idOf(item)) == id {
+				return item, nil
+			}
+			continue
+		}
+		if nameOf(item) == name {
+			if ok {
+				return zero, fmt.Errorf("more than one %s named '%s' found, use %s instead", kind, name, hint)
+			}
+			found, ok = item, true
+		}
+	}
+
+	if !ok {
+		if id != "" {
+			return zero, fmt.Errorf("couldn't find %s with id '%s'", kind, id)
+		}
+		return zero, fmt.Errorf("couldn't find %s '%s'", kind, name)
+	}
+
+	return found, nil
+}
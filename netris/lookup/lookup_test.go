@@ -0,0 +1,69 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lookup
+
+import "testing"
+
+type object struct {
+	ID   int
+	Name string
+}
+
+func TestFind(t *testing.T) {
+	list := []*object{
+		{ID: 1, Name: "a"},
+		{ID: 2, Name: "b"},
+		{ID: 3, Name: "b"},
+	}
+	idOf := func(o *object) int { return o.ID }
+	nameOf := func(o *object) string { return o.Name }
+
+	cases := []struct {
+		id, name string
+		want     int
+		err      string
+	}{
+		{id: "1", want: 1},
+		{id: "3", name: "a", want: 3},
+		{name: "a", want: 1},
+		{id: "4", err: "couldn't find object with id '4'"},
+		{id: "x", err: "couldn't find object with id 'x'"},
+		{name: "c", err: "couldn't find object 'c'"},
+		{name: "b", err: "more than one object named 'b' found, use id or parentid instead"},
+	}
+
+	for _, c := range cases {
+		got, err := Find(list, c.id, c.name, idOf, nameOf, "object", "id or parentid")
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Find(%q, %q) error = %v, want %q", c.id, c.name, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Find(%q, %q) returned error: %v", c.id, c.name, err)
+			continue
+		}
+		if got.ID != c.want {
+			t.Errorf("Find(%q, %q) = %d, want %d", c.id, c.name, got.ID, c.want)
+		}
+	}
+
+	if _, err := Find([]*object{}, "", "a", idOf, nameOf, "object", "id"); err == nil {
+		t.Errorf("Find on an empty list returned no error")
+	}
+}
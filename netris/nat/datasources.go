@@ -0,0 +1,202 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nat
+
+import (
+	"strconv"
+	"strings"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func ListDataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: NAT Rules",
+		Schema: map[string]*schema.Schema{
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only rules of this site.",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAction,
+				Description:  "Return only rules with this action. Possible values: `DNAT`, `SNAT`, `ACCEPT_SNAT`, `MASQUERADE`.",
+			},
+			"vpcid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only rules of this VPC.",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching NAT rules.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The NAT rule ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The NAT rule name.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule state. `enabled` or `disabled`.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule comment.",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule action.",
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Matched protocol.",
+						},
+						"siteid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The site ID of the rule.",
+						},
+						"vpcid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of VPC.",
+						},
+						"portgroupid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the port group used as destination ports.",
+						},
+						"srcaddress": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Matched source address.",
+						},
+						"srcport": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Matched source port.",
+						},
+						"dstaddress": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Matched destination address.",
+						},
+						"dstport": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Matched destination port.",
+						},
+						"dnattoip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The internal IP address to which external hosts will gain access.",
+						},
+						"dnattoport": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The internal port to which external port will be translated.",
+						},
+						"snattoip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Source NAT address.",
+						},
+						"snattopool": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Source NAT pool.",
+						},
+					},
+				},
+			},
+		},
+		Read: listDataResourceRead,
+	}
+}
+
+func listDataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	siteID := d.Get("siteid").(int)
+	action := d.Get("action").(string)
+	vpcid := d.Get("vpcid").(int)
+
+	nats, err := clientset.NAT().Get()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, n := range nats {
+		if siteID > 0 && n.Site.ID != siteID {
+			continue
+		}
+		if action != "" && n.Action.Value != action {
+			continue
+		}
+		if vpcid > 0 && n.Vpc.ID != vpcid {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(n.ID))
+		items = append(items, map[string]interface{}{
+			"id":          n.ID,
+			"name":        n.Name,
+			"state":       n.State.Value,
+			"comment":     n.Comment,
+			"action":      n.Action.Value,
+			"protocol":    n.Protocol.Value,
+			"siteid":      n.Site.ID,
+			"vpcid":       n.Vpc.ID,
+			"portgroupid": n.PortGroup.ID,
+			"srcaddress":  n.SourceAddress,
+			"srcport":     n.SourcePort,
+			"dstaddress":  n.DestinationAddress,
+			"dstport":     n.DestinationPort,
+			"dnattoip":    n.DnatToIP,
+			"dnattoport":  n.DnatToPort,
+			"snattoip":    n.SnatToIP,
+			"snattopool":  n.SnatToPool,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
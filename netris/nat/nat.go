@@ -25,6 +25,7 @@ import (
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/nat"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -75,7 +76,8 @@ func Resource() *schema.Resource {
 				Description:  "Possible values: `all`, `tcp`, `udp`, `icmp`",
 			},
 			"srcaddress": {
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Required:     true,
 				Type:         schema.TypeString,
 				Description:  "Match traffic sourced from this subnet",
@@ -87,7 +89,8 @@ func Resource() *schema.Resource {
 				Description: "Match traffic sourced from this port. Ignoring when protocol == `all` or `icmp`",
 			},
 			"dstaddress": {
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Required:     true,
 				Type:         schema.TypeString,
 				Description:  "Match traffic destined to this subnet",
@@ -100,7 +103,8 @@ func Resource() *schema.Resource {
 			},
 			"dnattoip": {
 				Computed:     true,
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Optional:     true,
 				Type:         schema.TypeString,
 				Description:  "The internal IP address to which external hosts will gain access as a result of a DNAT translation. Only when action == `DNAT`",
@@ -113,14 +117,16 @@ func Resource() *schema.Resource {
 			},
 			"snattoip": {
 				Computed:     true,
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Optional:     true,
 				Type:         schema.TypeString,
 				Description:  "Replace the original address with the specified one. Only when action == `SNAT`",
 			},
 			"snattopool": {
 				Computed:     true,
-				ValidateFunc: validateIPPrefix,
+				ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+				StateFunc:    ipaddr.StateFunc,
 				Optional:     true,
 				Type:         schema.TypeString,
 				Description:  "Replace the original address with the pool of ip addresses. Only when action == `SNAT`",
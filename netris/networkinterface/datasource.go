@@ -19,8 +19,10 @@ package networkinterface
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/netrisai/netriswebapi/v2/types/port"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -30,10 +32,19 @@ func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data Source: Network Interface",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the network interface",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Network Interface's exact name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "Network Interface's exact name (`swp1@switch01`), or only the network interface name (`swp1`) when `nodeid` is set",
 			},
 			"description": {
 				Computed:    true,
@@ -45,7 +56,7 @@ func DataResource() *schema.Resource {
 				Computed:    true,
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "The node ID to whom this network interface belongs",
+				Description: "The node ID to whom this network interface belongs. When set, only network interfaces of this node are searched",
 			},
 			"tenantid": {
 				Computed:    true,
@@ -112,25 +123,16 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	name := d.Get("name").(string)
-	var hwPort *port.Port
-
-	ports, err := clientset.Port().Get()
+	hwPort, err := findPort(clientset, d.Get("id").(string), d.Get("name").(string), d.Get("nodeid").(int))
 	if err != nil {
 		return err
 	}
-	for _, p := range ports {
-		if fmt.Sprintf("%s@%s", p.Port, p.Switch.Name) == name {
-			hwPort = p
-			break
-		}
-	}
-
-	if hwPort == nil {
-		return fmt.Errorf("Coudn't find network interface %s", name)
-	}
 
 	d.SetId(strconv.Itoa(hwPort.ID))
+	err = d.Set("name", fmt.Sprintf("%s@%s", hwPort.Port, hwPort.Switch.Name))
+	if err != nil {
+		return err
+	}
 	err = d.Set("description", hwPort.Description)
 	if err != nil {
 		return err
@@ -195,6 +197,34 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// findPort looks the network interface up by ID, or by name optionally scoped
+// to a node. Without a node the name must be in the `interface@node` form.
+func findPort(clientset *api.Clientset, id, name string, nodeID int) (*port.Port, error) {
+	ports, err := clientset.Port().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	nameOf := func(p *port.Port) string { return fmt.Sprintf("%s@%s", p.Port, p.Switch.Name) }
+	if id == "" && nodeID > 0 {
+		onSwitch := []*port.Port{}
+		for _, p := range ports {
+			if p.Switch.ID == nodeID {
+				onSwitch = append(onSwitch, p)
+			}
+		}
+		ports = onSwitch
+		if !strings.Contains(name, "@") {
+			nameOf = func(p *port.Port) string { return p.Port }
+		}
+	}
+
+	return lookup.Find(ports, id, name,
+		func(p *port.Port) int { return p.ID },
+		nameOf,
+		"network interface", "id or nodeid")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	return true, nil
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nos
+
+import (
+	"strconv"
+	"strings"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Network Operating Systems",
+		Schema: map[string]*schema.Schema{
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of network operating systems supported by the controller.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The NOS ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Human readable NOS name. Example: `Cumulus Linux`",
+						},
+						"tag": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value to use in the `nos` attribute of `netris_switch`. Example: `cumulus_linux`",
+						},
+					},
+				},
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Tags of all supported network operating systems.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	list, err := clientset.Inventory().GetNOS()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	tags := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, nos := range list {
+		ids = append(ids, strconv.Itoa(nos.ID))
+		tags = append(tags, nos.Tag)
+		items = append(items, map[string]interface{}{
+			"id":   nos.ID,
+			"name": nos.Name,
+			"tag":  nos.Tag,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+	err = d.Set("tags", tags)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
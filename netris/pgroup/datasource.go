@@ -0,0 +1,100 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgroup
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/netrisai/netriswebapi/v1/types/permission"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Permission Groups",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the Permission Group",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the Permission Group",
+			},
+			"description": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "Permission Group description",
+			},
+			"groups": {
+				Computed: true,
+				Type:     schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "List of groups in the same format as the `groups` attribute of the `netris_permission_group` resource. Example: `[\"services.l4loadbalancer:view\"]`",
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	id := d.Get("id").(string)
+	name := d.Get("name").(string)
+
+	groups, err := clientset.Permission().Get()
+	if err != nil {
+		return err
+	}
+
+	gr, err := lookup.Find(groups, id, name,
+		func(g *permission.PermissionGroup) int { return g.ID },
+		func(g *permission.PermissionGroup) string { return g.Name },
+		"permission group", "id")
+	if err != nil {
+		return err
+	}
+
+	externalACL := gr.ExternalAcl == "true" || gr.ExternalAcl == "t" || gr.ExternalAcl == "1"
+
+	d.SetId(strconv.Itoa(gr.ID))
+	err = d.Set("name", gr.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("description", gr.Description)
+	if err != nil {
+		return err
+	}
+	err = d.Set("groups", formatGroups(gr.Hidden.List(), gr.Readonly.List(), externalACL, mappings.getMap()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ package pgroup
 
 import (
 	"regexp"
+	"sort"
 )
 
 func parseGroups(s string) map[string]map[string][]string {
@@ -93,6 +94,71 @@ func makePermLists(exceptHidden, exceptReadOnly map[string]int, sections []strin
 	return hiddenList, readOnlyList
 }
 
+// formatGroups is the reverse of parseGroups and makeExceptionList: it turns
+// the hidden and read-only section lists stored by the controller back into
+// the `section.sub:action` form used in the `groups` attribute.
+func formatGroups(hiddenList, readOnlyList []string, externalACL bool, mappings map[string]map[string]string) []string {
+	hidden := make(map[string]bool)
+	for _, name := range hiddenList {
+		hidden[name] = true
+	}
+	readOnly := make(map[string]bool)
+	for _, name := range readOnlyList {
+		readOnly[name] = true
+	}
+
+	keys := []string{}
+	for key := range mappings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	groups := []string{}
+	for _, key := range keys {
+		main := mappings[key]["main"]
+		subkeys := []string{}
+		for subkey := range mappings[key] {
+			if subkey != "main" {
+				subkeys = append(subkeys, subkey)
+			}
+		}
+		sort.Strings(subkeys)
+
+		allVisible, allEditable := !hidden[main], !readOnly[main]
+		for _, subkey := range subkeys {
+			section := mappings[key][subkey]
+			if hidden[section] {
+				allVisible = false
+			}
+			if readOnly[section] {
+				allEditable = false
+			}
+		}
+
+		if allVisible && allEditable {
+			groups = append(groups, key+":edit")
+		} else {
+			if allVisible {
+				groups = append(groups, key+":view")
+			}
+			for _, subkey := range subkeys {
+				section := mappings[key][subkey]
+				if !readOnly[section] {
+					groups = append(groups, key+"."+subkey+":edit")
+				} else if !allVisible && !hidden[section] {
+					groups = append(groups, key+"."+subkey+":view")
+				}
+			}
+		}
+
+		if key == "services" && externalACL {
+			groups = append(groups, "services.acl:external-acl")
+		}
+	}
+
+	return groups
+}
+
 func regParser(valueMatch []string, subexpNames []string) map[string]string {
 	result := make(map[string]string)
 	if len(subexpNames) == len(valueMatch) {
@@ -0,0 +1,90 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgroup
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// permLists does what resourceCreate does with the `groups` attribute.
+func permLists(groups []string) (hidden, readOnly []string, externalACL bool) {
+	groupParameters := parseGroups(strings.Join(groups, ","))
+	exceptHidden, exceptReadOnly := makeExceptionList(groupParameters, mappings.getMap())
+	hidden, readOnly = makePermLists(exceptHidden, exceptReadOnly, sectionNames)
+	for _, val := range groupParameters["services"]["acl"] {
+		if val == "external-acl" {
+			externalACL = true
+		}
+	}
+	sort.Strings(hidden)
+	sort.Strings(readOnly)
+	return hidden, readOnly, externalACL
+}
+
+func TestFormatGroups(t *testing.T) {
+	cases := []struct {
+		groups []string
+		want   []string
+	}{
+		{
+			groups: []string{"services.l4loadbalancer:view"},
+			want:   []string{"services.l4loadbalancer:view"},
+		},
+		{
+			groups: []string{"net:edit"},
+			want:   []string{"net:edit"},
+		},
+		{
+			groups: []string{"api:view"},
+			want:   []string{"api:view"},
+		},
+		{
+			groups: []string{"net:view", "net.ipam:edit"},
+			want:   []string{"net:view", "net.ipam:edit"},
+		},
+		{
+			groups: []string{"services.acl:edit", "services.acl:external-acl"},
+			want:   []string{"services.acl:edit", "services.acl:external-acl"},
+		},
+		{
+			groups: []string{"accounts:edit", "api:edit", "net:edit", "services:edit", "settings:edit"},
+			want:   []string{"accounts:edit", "api:edit", "net:edit", "services:edit", "settings:edit"},
+		},
+		{
+			groups: []string{"settings.checks:view", "settings.general:edit", "accounts.users:view"},
+			want:   []string{"accounts.users:view", "settings.checks:view", "settings.general:edit"},
+		},
+	}
+
+	for _, c := range cases {
+		hidden, readOnly, externalACL := permLists(c.groups)
+		got := formatGroups(hidden, readOnly, externalACL, mappings.getMap())
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("formatGroups(%v) = %v, want %v", c.groups, got, c.want)
+		}
+
+		// The data source output must be valid input for the resource and
+		// grant exactly the same permissions.
+		h, r, e := permLists(got)
+		if !reflect.DeepEqual(h, hidden) || !reflect.DeepEqual(r, readOnly) || e != externalACL {
+			t.Errorf("groups %v formatted as %v don't round-trip", c.groups, got)
+		}
+	}
+}
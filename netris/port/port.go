@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/netrisai/netriswebapi/v2/types/port"
+	"github.com/netrisai/terraform-provider-netris/netris/vlancheck"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
@@ -109,11 +110,12 @@ func Resource() *schema.Resource {
 				},
 			},
 		},
-		Create: resourceCreate,
-		Read:   resourceRead,
-		Update: resourceUpdate,
-		Delete: resourceDelete,
-		Exists: resourceExists,
+		Create:        resourceCreate,
+		Read:          resourceRead,
+		Update:        resourceUpdate,
+		Delete:        resourceDelete,
+		Exists:        resourceExists,
+		CustomizeDiff: customizeDiff,
 		// Importer: &schema.ResourceImporter{
 		// 	State: resourceImport,
 		// },
@@ -414,3 +416,35 @@ func resourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 
 	return true, nil
 }
+
+// customizeDiff checks the VLAN range of a new extension against the site and
+// the VLANs already in use on the port. Existing extensions are left alone.
+func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("extension") || !d.NewValueKnown("extension") ||
+		!d.NewValueKnown("switchid") || !d.NewValueKnown("name") {
+		return nil
+	}
+	ext := d.Get("extension").(map[string]interface{})
+	vlanrange, ok := ext["vlanrange"].(string)
+	if !ok || vlanrange == "" {
+		return nil
+	}
+
+	clientset := m.(*api.Clientset)
+	name, _ := ext["extensionname"].(string)
+	if _, ok := findExtensionByName(name, clientset); ok {
+		return nil
+	}
+
+	ports, err := clientset.Port().GetBySwId(d.Get("switchid").(int))
+	if err != nil {
+		return err
+	}
+	for _, p := range ports {
+		if p.Port == d.Get("name").(string) {
+			return vlancheck.New(clientset).Extension(vlanrange, p.ID, "")
+		}
+	}
+
+	return nil
+}
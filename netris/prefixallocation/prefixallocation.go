@@ -0,0 +1,360 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prefixallocation
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/netip"
+	"strconv"
+	"sync"
+
+	"github.com/netrisai/netriswebapi/http"
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/terraform-provider-netris/netris/allocation"
+	"github.com/netrisai/terraform-provider-netris/netris/subnet"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// allocateMu serializes prefix selection so that resources created in the
+// same apply never pick the same free prefix.
+var allocateMu sync.Mutex
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Allocates the next available prefix from an Allocation",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique name for the subnet created from the allocated prefix.",
+			},
+			"allocationid": {
+				ForceNew:    true,
+				Required:    true,
+				Type:        schema.TypeInt,
+				Description: "ID of the parent allocation to pick the prefix from.",
+			},
+			"prefixlength": {
+				ForceNew:    true,
+				Required:    true,
+				Type:        schema.TypeInt,
+				Description: "Length of the prefix to allocate. Example: `24`",
+			},
+			"prefix": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "The allocated prefix. Stays the same for the lifetime of the resource.",
+			},
+			"tenantid": {
+				Required:    true,
+				Type:        schema.TypeInt,
+				Description: "ID of tenant. Users of this tenant will be permitted to manage the subnet.",
+			},
+			"vpcid": {
+				ForceNew:    true,
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "ID of VPC of the parent allocation. If not specified, the VPC marked as a default is used.",
+			},
+			"purpose": {
+				Required:    true,
+				Type:        schema.TypeString,
+				Description: "Describes which kind of service will be able to use this subnet. Possible values: `common`, `loopback`, `management`, `load-balancer`, `nat`, `inactive`",
+			},
+			"siteids": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Description: "List of sites IDs where this subnet is available.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+		Create: resourceCreate,
+		Read:   resourceRead,
+		Update: resourceUpdate,
+		Delete: resourceDelete,
+		Exists: resourceExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceImport,
+		},
+	}
+}
+
+func getIPAM(clientset *api.Clientset, vpcid int) ([]*ipam.IPAM, error) {
+	if vpcid > 0 {
+		return clientset.IPAM().GetByVPC(vpcid)
+	}
+	return clientset.IPAM().Get()
+}
+
+func getSubnets(clientset *api.Clientset, vpcid int) ([]*ipam.IPAM, error) {
+	if vpcid > 0 {
+		return clientset.IPAM().GetSubnetsByVPC(vpcid)
+	}
+	return clientset.IPAM().GetSubnets()
+}
+
+func subnetFromResource(d *schema.ResourceData, prefix string) *ipam.Subnet {
+	sitesList := d.Get("siteids").([]interface{})
+	sites := []ipam.IDName{}
+	for _, s := range sitesList {
+		sites = append(sites, ipam.IDName{ID: s.(int)})
+	}
+
+	return &ipam.Subnet{
+		Name:    d.Get("name").(string),
+		Prefix:  prefix,
+		Tenant:  ipam.IDName{ID: d.Get("tenantid").(int)},
+		Purpose: d.Get("purpose").(string),
+		Sites:   sites,
+		Tags:    []string{},
+	}
+}
+
+func resourceCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] prefixallocation resourceCreate")
+	clientset := m.(*api.Clientset)
+
+	allocationID := d.Get("allocationid").(int)
+	length := d.Get("prefixlength").(int)
+	vpcid := d.Get("vpcid").(int)
+
+	allocateMu.Lock()
+	defer allocateMu.Unlock()
+
+	ipams, err := getIPAM(clientset, vpcid)
+	if err != nil {
+		return err
+	}
+
+	parent := allocation.GetByID(ipams, allocationID)
+	if parent == nil {
+		return fmt.Errorf("allocation '%d' not found", allocationID)
+	}
+
+	parentPrefix, err := netip.ParsePrefix(parent.Prefix)
+	if err != nil {
+		return fmt.Errorf("allocation '%s' has invalid prefix: %s", parent.Name, err)
+	}
+
+	prefix, err := nextAvailablePrefix(parentPrefix, length, usedPrefixes(parent.Children))
+	if err != nil {
+		return fmt.Errorf("allocation '%s': %s", parent.Name, err)
+	}
+
+	subnetAdd := subnetFromResource(d, prefix.String())
+	if vpcid > 0 {
+		subnetAdd.Vpc = &ipam.IDName{ID: vpcid}
+	}
+
+	js, _ := json.Marshal(subnetAdd)
+	log.Println("[DEBUG]", string(js))
+
+	reply, err := clientset.IPAM().AddSubnet(subnetAdd)
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	log.Println("[DEBUG]", string(reply.Data))
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", string(reply.Data))
+	}
+
+	idStruct := struct {
+		ID int `json:"id"`
+	}{}
+
+	data, err := reply.Parse()
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	err = http.Decode(data.Data, &idStruct)
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	log.Println("[DEBUG] ID:", idStruct.ID)
+
+	d.SetId(strconv.Itoa(idStruct.ID))
+	err = d.Set("prefix", prefix.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] prefixallocation resourceRead")
+	clientset := m.(*api.Clientset)
+
+	ipams, err := getSubnets(clientset, d.Get("vpcid").(int))
+	if err != nil {
+		return err
+	}
+	id, _ := strconv.Atoi(d.Id())
+	item := subnet.GetByID(ipams, id)
+	if item == nil {
+		return nil
+	}
+
+	d.SetId(strconv.Itoa(item.ID))
+	err = d.Set("name", item.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("prefix", item.Prefix)
+	if err != nil {
+		return err
+	}
+	if p, err := netip.ParsePrefix(item.Prefix); err == nil {
+		err = d.Set("prefixlength", p.Bits())
+		if err != nil {
+			return err
+		}
+	}
+	err = d.Set("tenantid", item.Tenant.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("purpose", item.Purpose)
+	if err != nil {
+		return err
+	}
+	sites := []int{}
+	for _, s := range item.Sites {
+		sites = append(sites, s.ID)
+	}
+	err = d.Set("siteids", sites)
+	if err != nil {
+		return err
+	}
+	if d.Get("allocationid").(int) == 0 && item.AllocationID > 0 {
+		err = d.Set("allocationid", item.AllocationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] prefixallocation resourceUpdate")
+	clientset := m.(*api.Clientset)
+
+	subnetUpdate := subnetFromResource(d, d.Get("prefix").(string))
+
+	js, _ := json.Marshal(subnetUpdate)
+	log.Println("[DEBUG]", string(js))
+
+	id, _ := strconv.Atoi(d.Id())
+	reply, err := clientset.IPAM().UpdateSubnet(id, subnetUpdate)
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	log.Println("[DEBUG]", string(reply.Data))
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", string(reply.Data))
+	}
+
+	return nil
+}
+
+func resourceDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] prefixallocation resourceDelete")
+	clientset := m.(*api.Clientset)
+
+	id, _ := strconv.Atoi(d.Id())
+	reply, err := clientset.IPAM().Delete("subnet", id)
+	if err != nil {
+		return err
+	}
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", string(reply.Data))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	log.Println("[DEBUG] prefixallocation resourceExists")
+	clientset := m.(*api.Clientset)
+
+	ipams, err := getSubnets(clientset, d.Get("vpcid").(int))
+	if err != nil {
+		return false, err
+	}
+	id, _ := strconv.Atoi(d.Id())
+	if item := subnet.GetByID(ipams, id); item == nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func resourceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Println("[DEBUG] prefixallocation resourceImport")
+	clientset := m.(*api.Clientset)
+
+	ipams, err := clientset.IPAM().GetSubnets()
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	prefix := d.Id()
+	item := subnet.GetByPrefix(ipams, prefix)
+	if item == nil {
+		return []*schema.ResourceData{d}, fmt.Errorf("subnet '%s' not found", prefix)
+	}
+
+	itemPrefix, err := netip.ParsePrefix(item.Prefix)
+	if err != nil {
+		return []*schema.ResourceData{d}, fmt.Errorf("subnet '%s' has invalid prefix: %s", item.Name, err)
+	}
+	ipams, err = clientset.IPAM().Get()
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	parent := parentAllocation(ipams, itemPrefix.Masked())
+	if parent == nil {
+		return []*schema.ResourceData{d}, fmt.Errorf("couldn't find the allocation of subnet '%s'", prefix)
+	}
+
+	d.SetId(strconv.Itoa(item.ID))
+	err = d.Set("allocationid", parent.ID)
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
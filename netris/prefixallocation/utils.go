@@ -0,0 +1,117 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prefixallocation
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+)
+
+// usedPrefixes collects the prefixes of every IPAM entry nested under the
+// given allocation. Entries that fail to parse are skipped.
+func usedPrefixes(list []*ipam.IPAM) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, s := range list {
+		if p, err := netip.ParsePrefix(s.Prefix); err == nil {
+			prefixes = append(prefixes, p.Masked())
+		}
+		if len(s.Children) > 0 {
+			prefixes = append(prefixes, usedPrefixes(s.Children)...)
+		}
+	}
+	return prefixes
+}
+
+// parentAllocation returns the most specific allocation that contains the
+// prefix, or nil if there is none.
+func parentAllocation(list []*ipam.IPAM, prefix netip.Prefix) *ipam.IPAM {
+	var parent *ipam.IPAM
+	bits := -1
+	for _, s := range list {
+		candidate := s
+		if s.Type != "allocation" {
+			candidate = nil
+		}
+		if len(s.Children) > 0 {
+			if p := parentAllocation(s.Children, prefix); p != nil {
+				candidate = p
+			}
+		}
+		if candidate == nil {
+			continue
+		}
+
+		p, err := netip.ParsePrefix(candidate.Prefix)
+		if err != nil || p.Bits() > prefix.Bits() || !p.Masked().Contains(prefix.Addr()) {
+			continue
+		}
+		if p.Bits() > bits {
+			parent, bits = candidate, p.Bits()
+		}
+	}
+	return parent
+}
+
+// nextAvailablePrefix returns the lowest prefix of the requested length inside
+// parent that does not overlap any of the used prefixes.
+func nextAvailablePrefix(parent netip.Prefix, length int, used []netip.Prefix) (netip.Prefix, error) {
+	parent = parent.Masked()
+	if length < parent.Bits() || length > parent.Addr().BitLen() {
+		return netip.Prefix{}, fmt.Errorf("prefix length /%d is out of range for %s", length, parent)
+	}
+
+	candidate := netip.PrefixFrom(parent.Addr(), length)
+	for parent.Contains(candidate.Addr()) {
+		var conflict *netip.Prefix
+		for i := range used {
+			if used[i].Overlaps(candidate) {
+				conflict = &used[i]
+				break
+			}
+		}
+		if conflict == nil {
+			return candidate, nil
+		}
+
+		// Both prefixes are aligned to their own length, so the address right
+		// after the larger of the two is always aligned to the candidate length.
+		last := lastAddr(candidate)
+		if conflict.Bits() < candidate.Bits() {
+			last = lastAddr(*conflict)
+		}
+		next := last.Next()
+		if !next.IsValid() {
+			break
+		}
+		candidate = netip.PrefixFrom(next, length)
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d prefix left in %s", length, parent)
+}
+
+// lastAddr returns the highest address covered by the prefix.
+func lastAddr(p netip.Prefix) netip.Addr {
+	p = p.Masked()
+	b := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - uint(i%8))
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prefixallocation
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+)
+
+func TestNextAvailablePrefix(t *testing.T) {
+	cases := []struct {
+		name   string
+		parent string
+		length int
+		used   []string
+		want   string
+		err    bool
+	}{
+		{
+			name:   "empty allocation",
+			parent: "10.0.0.0/16",
+			length: 24,
+			want:   "10.0.0.0/24",
+		},
+		{
+			name:   "skips used prefixes",
+			parent: "10.0.0.0/16",
+			length: 24,
+			used:   []string{"10.0.0.0/24", "10.0.1.0/24"},
+			want:   "10.0.2.0/24",
+		},
+		{
+			name:   "fills a gap",
+			parent: "10.0.0.0/16",
+			length: 24,
+			used:   []string{"10.0.0.0/24", "10.0.2.0/24"},
+			want:   "10.0.1.0/24",
+		},
+		{
+			name:   "smaller used prefix blocks the whole candidate",
+			parent: "10.0.0.0/16",
+			length: 24,
+			used:   []string{"10.0.0.128/25", "10.0.1.200/32"},
+			want:   "10.0.2.0/24",
+		},
+		{
+			name:   "larger used prefix is skipped at once",
+			parent: "10.0.0.0/16",
+			length: 26,
+			used:   []string{"10.0.0.0/20"},
+			want:   "10.0.16.0/26",
+		},
+		{
+			name:   "unaligned parent is masked",
+			parent: "192.0.2.77/24",
+			length: 26,
+			used:   []string{"192.0.2.0/26"},
+			want:   "192.0.2.64/26",
+		},
+		{
+			name:   "other family is ignored",
+			parent: "192.0.2.0/24",
+			length: 25,
+			used:   []string{"2001:db8::/32"},
+			want:   "192.0.2.0/25",
+		},
+		{
+			name:   "ipv6",
+			parent: "2001:db8:acad::/48",
+			length: 64,
+			used:   []string{"2001:db8:acad::/64", "2001:db8:acad:1::/64", "2001:db8:acad:2::/63"},
+			want:   "2001:db8:acad:4::/64",
+		},
+		{
+			name:   "exhausted",
+			parent: "192.0.2.0/24",
+			length: 25,
+			used:   []string{"192.0.2.0/25", "192.0.2.128/25"},
+			err:    true,
+		},
+		{
+			name:   "exhausted at the top of the address space",
+			parent: "255.255.255.0/24",
+			length: 24,
+			used:   []string{"255.255.255.0/24"},
+			err:    true,
+		},
+		{
+			name:   "length shorter than parent",
+			parent: "10.0.0.0/16",
+			length: 8,
+			err:    true,
+		},
+		{
+			name:   "length longer than address",
+			parent: "10.0.0.0/16",
+			length: 33,
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var used []netip.Prefix
+			for _, u := range tc.used {
+				used = append(used, netip.MustParsePrefix(u))
+			}
+			got, err := nextAvailablePrefix(netip.MustParsePrefix(tc.parent), tc.length, used)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Fatalf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUsedPrefixes(t *testing.T) {
+	list := []*ipam.IPAM{
+		{Prefix: "10.0.0.0/24", Children: []*ipam.IPAM{
+			{Prefix: "10.0.0.0/26"},
+		}},
+		{Prefix: "10.0.1.5/24"},
+		{Prefix: "garbage"},
+	}
+
+	got := usedPrefixes(list)
+	want := []string{"10.0.0.0/24", "10.0.0.0/26", "10.0.1.0/24"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestParentAllocation(t *testing.T) {
+	list := []*ipam.IPAM{
+		{ID: 1, Type: "allocation", Prefix: "10.0.0.0/8", Children: []*ipam.IPAM{
+			{ID: 2, Type: "allocation", Prefix: "10.1.0.0/16", Children: []*ipam.IPAM{
+				{ID: 3, Type: "subnet", Prefix: "10.1.2.0/24"},
+			}},
+			{ID: 4, Type: "subnet", Prefix: "10.2.0.0/24"},
+		}},
+		{ID: 5, Type: "allocation", Prefix: "192.0.2.0/24"},
+	}
+
+	cases := []struct {
+		prefix string
+		want   int
+	}{
+		{prefix: "10.1.2.0/24", want: 2},
+		{prefix: "10.2.0.0/24", want: 1},
+		{prefix: "192.0.2.128/25", want: 5},
+		{prefix: "10.1.0.0/16", want: 2},
+		{prefix: "172.16.0.0/24"},
+		{prefix: "192.0.0.0/16"},
+	}
+
+	for _, c := range cases {
+		got := parentAllocation(list, netip.MustParsePrefix(c.prefix))
+		id := 0
+		if got != nil {
+			id = got.ID
+		}
+		if id != c.want {
+			t.Errorf("parentAllocation(%s) = %d, want %d", c.prefix, id, c.want)
+		}
+	}
+}
@@ -28,15 +28,20 @@ import (
 	"github.com/netrisai/terraform-provider-netris/netris/bgpobject"
 	"github.com/netrisai/terraform-provider-netris/netris/controller"
 	"github.com/netrisai/terraform-provider-netris/netris/dhcpoptionset"
+	"github.com/netrisai/terraform-provider-netris/netris/inventory"
 	"github.com/netrisai/terraform-provider-netris/netris/inventoryprofile"
+	"github.com/netrisai/terraform-provider-netris/netris/inventorystatus"
+	"github.com/netrisai/terraform-provider-netris/netris/ipreservation"
 	"github.com/netrisai/terraform-provider-netris/netris/l4lb"
 	"github.com/netrisai/terraform-provider-netris/netris/lag"
 	"github.com/netrisai/terraform-provider-netris/netris/link"
 	"github.com/netrisai/terraform-provider-netris/netris/nat"
 	"github.com/netrisai/terraform-provider-netris/netris/networkinterface"
+	"github.com/netrisai/terraform-provider-netris/netris/nos"
 	"github.com/netrisai/terraform-provider-netris/netris/pgroup"
 	"github.com/netrisai/terraform-provider-netris/netris/port"
 	"github.com/netrisai/terraform-provider-netris/netris/portgroup"
+	"github.com/netrisai/terraform-provider-netris/netris/prefixallocation"
 	"github.com/netrisai/terraform-provider-netris/netris/roh"
 	"github.com/netrisai/terraform-provider-netris/netris/route"
 	"github.com/netrisai/terraform-provider-netris/netris/routemap"
@@ -48,6 +53,7 @@ import (
 	"github.com/netrisai/terraform-provider-netris/netris/subnet"
 	"github.com/netrisai/terraform-provider-netris/netris/sw"
 	"github.com/netrisai/terraform-provider-netris/netris/tenant"
+	"github.com/netrisai/terraform-provider-netris/netris/topology"
 	"github.com/netrisai/terraform-provider-netris/netris/user"
 	"github.com/netrisai/terraform-provider-netris/netris/userrole"
 	"github.com/netrisai/terraform-provider-netris/netris/vnet"
@@ -74,50 +80,74 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"netris_vnet":                  vnet.Resource(),
-			"netris_bgp":                   bgp.Resource(),
-			"netris_l4lb":                  l4lb.Resource(),
-			"netris_allocation":            allocation.Resource(),
-			"netris_subnet":                subnet.Resource(),
-			"netris_tenant":                tenant.Resource(),
-			"netris_switch":                sw.Resource(),
-			"netris_controller":            controller.Resource(),
-			"netris_softgate":              softgate.Resource(),
-			"netris_server":                server.Resource(),
-			"netris_user_role":             userrole.Resource(),
-			"netris_user":                  user.Resource(),
-			"netris_permission_group":      pgroup.Resource(),
-			"netris_acl":                   acl.Resource(),
-			"netris_roh":                   roh.Resource(),
-			"netris_portgroup":             portgroup.Resource(),
-			"netris_inventory_profile":     inventoryprofile.Resource(),
-			"netris_bgp_object":            bgpobject.Resource(),
-			"netris_site":                  site.Resource(),
-			"netris_routemap":              routemap.Resource(),
-			"netris_link":                  link.Resource(),
-			"netris_nat":                   nat.Resource(),
-			"netris_port":                  port.Resource(),
-			"netris_network_interface":     networkinterface.Resource(),
-			"netris_route":                 route.Resource(),
-			"netris_acltwozero":            acl2.Resource(),
-			"netris_dhcp_option_set":       dhcpoptionset.Resource(),
-			"netris_vpc":                   vpc.Resource(),
-			"netris_lag":                   lag.Resource(),
-			"netris_servercluster":         servercluster.Resource(),
-			"netris_serverclustertemplate": serverclustertemplate.Resource(),
+			"netris_vnet":                   vnet.Resource(),
+			"netris_vnet_member":            vnet.MemberResource(),
+			"netris_vnet_gateway":           vnet.GatewayResource(),
+			"netris_bgp":                    bgp.Resource(),
+			"netris_l4lb":                   l4lb.Resource(),
+			"netris_allocation":             allocation.Resource(),
+			"netris_subnet":                 subnet.Resource(),
+			"netris_tenant":                 tenant.Resource(),
+			"netris_switch":                 sw.Resource(),
+			"netris_controller":             controller.Resource(),
+			"netris_softgate":               softgate.Resource(),
+			"netris_server":                 server.Resource(),
+			"netris_user_role":              userrole.Resource(),
+			"netris_user":                   user.Resource(),
+			"netris_permission_group":       pgroup.Resource(),
+			"netris_acl":                    acl.Resource(),
+			"netris_roh":                    roh.Resource(),
+			"netris_portgroup":              portgroup.Resource(),
+			"netris_inventory_profile":      inventoryprofile.Resource(),
+			"netris_bgp_object":             bgpobject.Resource(),
+			"netris_site":                   site.Resource(),
+			"netris_routemap":               routemap.Resource(),
+			"netris_link":                   link.Resource(),
+			"netris_nat":                    nat.Resource(),
+			"netris_port":                   port.Resource(),
+			"netris_network_interface":      networkinterface.Resource(),
+			"netris_route":                  route.Resource(),
+			"netris_acltwozero":             acl2.Resource(),
+			"netris_dhcp_option_set":        dhcpoptionset.Resource(),
+			"netris_vpc":                    vpc.Resource(),
+			"netris_lag":                    lag.Resource(),
+			"netris_servercluster":          servercluster.Resource(),
+			"netris_servercluster_member":   servercluster.MemberResource(),
+			"netris_serverclustertemplate":  serverclustertemplate.Resource(),
+			"netris_ipam_prefix_allocation": prefixallocation.Resource(),
+			"netris_ip_reservation":         ipreservation.Resource(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"netris_site":              site.DataResource(),
-			"netris_bgp_object":        bgpobject.DataResource(),
-			"netris_tenant":            tenant.DataResource(),
-			"netris_port":              port.DataResource(),
-			"netris_network_interface": networkinterface.DataResource(),
-			"netris_vnet":              vnet.DataResource(),
-			"netris_inventory_profile": inventoryprofile.DataResource(),
-			"netris_routemap":          routemap.DataResource(),
-			"netris_dhcp_option_set":   dhcpoptionset.DataResource(),
-			"netris_vpc":               vpc.DataResource(),
-			"netris_lag":               lag.DataResource(),
+			"netris_site":                  site.DataResource(),
+			"netris_bgp_object":            bgpobject.DataResource(),
+			"netris_tenant":                tenant.DataResource(),
+			"netris_port":                  port.DataResource(),
+			"netris_network_interface":     networkinterface.DataResource(),
+			"netris_vnet":                  vnet.DataResource(),
+			"netris_inventory_profile":     inventoryprofile.DataResource(),
+			"netris_routemap":              routemap.DataResource(),
+			"netris_dhcp_option_set":       dhcpoptionset.DataResource(),
+			"netris_vpc":                   vpc.DataResource(),
+			"netris_lag":                   lag.DataResource(),
+			"netris_softgate":              softgate.DataResource(),
+			"netris_server":                server.DataResource(),
+			"netris_controller":            controller.DataResource(),
+			"netris_inventory":             inventory.DataResource(),
+			"netris_user":                  user.DataResource(),
+			"netris_user_role":             userrole.DataResource(),
+			"netris_permission_group":      pgroup.DataResource(),
+			"netris_bgp":                   bgp.DataResource(),
+			"netris_bgps":                  bgp.ListDataResource(),
+			"netris_vnets":                 vnet.ListDataResource(),
+			"netris_nos":                   nos.DataResource(),
+			"netris_topology":              topology.DataResource(),
+			"netris_sites":                 site.ListDataResource(),
+			"netris_routes":                route.ListDataResource(),
+			"netris_nat_rules":             nat.ListDataResource(),
+			"netris_acls":                  acl.ListDataResource(),
+			"netris_l4lb":                  l4lb.DataResource(),
+			"netris_inventory_status":      inventorystatus.DataResource(),
+			"netris_serverclustertemplate": serverclustertemplate.DataResource(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
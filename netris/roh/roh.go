@@ -27,6 +27,7 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/roh"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 )
 
 func Resource() *schema.Resource {
@@ -68,7 +69,8 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of IPv4 addresses for the loopback interface.",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+					StateFunc:    ipaddr.StateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -77,7 +79,8 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeList,
 				Description: "List of anycast IP addresses",
 				Elem: &schema.Schema{
-					ValidateFunc: validateIP,
+					ValidateFunc: ipaddr.ValidateAddressOrPrefix,
+					StateFunc:    ipaddr.StateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -95,6 +98,7 @@ func Resource() *schema.Resource {
 				Description: "List of additional prefixes that the ROH server may advertise. Only when type == `hypervisor`",
 				Elem: &schema.Schema{
 					ValidateFunc: validatePrefixRule,
+					StateFunc:    prefixRuleStateFunc,
 					Type:         schema.TypeString,
 				},
 			},
@@ -17,31 +17,56 @@ limitations under the License.
 package roh
 
 import (
-	"regexp"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/netrisai/netriswebapi/v2/types/roh"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 )
 
-func parsePrefixList(prefix string) roh.InboundPrefixW {
-	re := regexp.MustCompile(`(?P<action>permit|deny) +(?P<ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\/\b([0-9]|[12][0-9]|3[0-2])\b) +(?P<cond>(le|ge) +\b([0-9]|[12][0-9]|3[0-2])\b)`)
-	sub := re.SubexpNames()
-	valueMatch := re.FindStringSubmatch(prefix)
-	v := regParser(valueMatch, sub)
-	return roh.InboundPrefixW{
-		Action:    v["action"],
-		Condition: v["cond"],
-		Subnet:    v["ip"],
+// parsePrefixRule parses an inbound prefix rule such as
+// `permit 10.0.0.0/8 le 24`.
+func parsePrefixRule(rule string) (roh.InboundPrefixW, error) {
+	fields := strings.Fields(rule)
+	if len(fields) != 4 {
+		return roh.InboundPrefixW{}, fmt.Errorf("expected 'permit|deny prefix le|ge length', got: %s", rule)
+	}
+	action, subnet, cond, length := fields[0], fields[1], fields[2], fields[3]
+
+	if action != "permit" && action != "deny" {
+		return roh.InboundPrefixW{}, fmt.Errorf("action must be permit or deny, got: %s", action)
+	}
+	prefix, err := ipaddr.ParsePrefix(subnet)
+	if err != nil || !prefix.Addr().Is4() {
+		return roh.InboundPrefixW{}, fmt.Errorf("expected an IPv4 prefix, got: %s", subnet)
+	}
+	if cond != "le" && cond != "ge" {
+		return roh.InboundPrefixW{}, fmt.Errorf("condition must be le or ge, got: %s", cond)
 	}
+	if n, err := strconv.Atoi(length); err != nil || n < 0 || n > 32 {
+		return roh.InboundPrefixW{}, fmt.Errorf("length must be between 0 and 32, got: %s", length)
+	}
+
+	return roh.InboundPrefixW{
+		Action:    action,
+		Condition: cond + " " + length,
+		Subnet:    prefix.String(),
+	}, nil
 }
 
-func regParser(valueMatch []string, subexpNames []string) map[string]string {
-	result := make(map[string]string)
-	if len(subexpNames) == len(valueMatch) {
-		for i, name := range subexpNames {
-			if name != "" {
-				result[name] = valueMatch[i]
-			}
-		}
+// prefixRuleStateFunc stores inbound prefix rules in canonical form, with
+// single spaces and the prefix as ipaddr.Canonical returns it.
+func prefixRuleStateFunc(val interface{}) string {
+	s, _ := val.(string)
+	rule, err := parsePrefixRule(s)
+	if err != nil {
+		return s
 	}
-	return result
+	return fmt.Sprintf("%s %s %s", rule.Action, rule.Subnet, rule.Condition)
+}
+
+func parsePrefixList(prefix string) roh.InboundPrefixW {
+	rule, _ := parsePrefixRule(prefix)
+	return rule
 }
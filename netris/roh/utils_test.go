@@ -0,0 +1,70 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roh
+
+import (
+	"testing"
+
+	"github.com/netrisai/netriswebapi/v2/types/roh"
+)
+
+func TestParsePrefixRule(t *testing.T) {
+	cases := []struct {
+		in   string
+		want roh.InboundPrefixW
+		err  bool
+	}{
+		{in: "permit 10.0.0.0/8 le 24", want: roh.InboundPrefixW{Action: "permit", Subnet: "10.0.0.0/8", Condition: "le 24"}},
+		{in: "deny  192.0.2.0/24   ge 32", want: roh.InboundPrefixW{Action: "deny", Subnet: "192.0.2.0/24", Condition: "ge 32"}},
+		{in: "allow 10.0.0.0/8 le 24", err: true},
+		{in: "permit 10.0.0.256/8 le 24", err: true},
+		{in: "permit 10.0.0.0/33 le 24", err: true},
+		{in: "permit 10.0.0.0 le 24", err: true},
+		{in: "permit 2001:db8::/32 le 48", err: true},
+		{in: "permit 10.0.0.0/8 eq 24", err: true},
+		{in: "permit 10.0.0.0/8 le 33", err: true},
+		{in: "permit 10.0.0.0/8", err: true},
+		{in: "permit 10.0.0.0/8 le 24 extra", err: true},
+	}
+
+	for _, c := range cases {
+		got, err := parsePrefixRule(c.in)
+		if (err != nil) != c.err {
+			t.Errorf("parsePrefixRule(%q) error = %v, want error %t", c.in, err, c.err)
+			continue
+		}
+		if !c.err && got != c.want {
+			t.Errorf("parsePrefixRule(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestPrefixRuleStateFunc(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"permit  10.0.0.0/8   le 24", "permit 10.0.0.0/8 le 24"},
+		{"deny 192.0.2.0/24 ge 32", "deny 192.0.2.0/24 ge 32"},
+		{"not a rule", "not a rule"},
+	}
+
+	for _, c := range cases {
+		if got := prefixRuleStateFunc(c.in); got != c.want {
+			t.Errorf("prefixRuleStateFunc(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
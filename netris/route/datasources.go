@@ -0,0 +1,186 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v1/types/route"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func ListDataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Routes",
+		Schema: map[string]*schema.Schema{
+			"vpcid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only routes of this VPC.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only routes of this site.",
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Return only routes whose prefix is within this prefix. Example: `10.0.0.0/8`",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching routes.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The route ID.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Route description.",
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Route destination.",
+						},
+						"nexthop": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Traffic destined to the prefix will be routed towards this address.",
+						},
+						"siteid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The site ID of the route.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Route state. `enabled` or `disabled`.",
+						},
+						"vpcid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of VPC.",
+						},
+						"hwids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "IDs of the switches the route is applied on. Empty means all switches of the site.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+					},
+				},
+			},
+		},
+		Read: listDataResourceRead,
+	}
+}
+
+// within reports whether p is inside of parent.
+func within(p, parent netip.Prefix) bool {
+	return parent.Bits() <= p.Bits() && parent.Contains(p.Addr())
+}
+
+func listDataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vpcid := d.Get("vpcid").(int)
+	siteID := d.Get("siteid").(int)
+
+	var parent netip.Prefix
+	if p := d.Get("prefix").(string); p != "" {
+		var err error
+		parent, err = netip.ParsePrefix(p)
+		if err != nil {
+			return err
+		}
+		parent = parent.Masked()
+	}
+
+	var routes []*route.Route
+	var err error
+	if vpcid > 0 {
+		routes, err = clientset.Route().GetByVPC(vpcid)
+	} else {
+		routes, err = clientset.Route().Get()
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, r := range routes {
+		if vpcid > 0 && r.Vpc.ID != vpcid {
+			continue
+		}
+		if siteID > 0 && r.SiteID != siteID {
+			continue
+		}
+		prefix := fmt.Sprintf("%s/%d", r.Prefix, r.PrefixLength)
+		if parent.IsValid() {
+			p, err := netip.ParsePrefix(prefix)
+			if err != nil || !within(p, parent) {
+				continue
+			}
+		}
+
+		hwids := []int{}
+		for _, s := range r.FilteredSwitches {
+			hwids = append(hwids, s.ID)
+		}
+
+		ids = append(ids, strconv.Itoa(r.ID))
+		items = append(items, map[string]interface{}{
+			"id":          r.ID,
+			"description": r.Description,
+			"prefix":      prefix,
+			"nexthop":     r.NextHop,
+			"siteid":      r.SiteID,
+			"state":       r.State,
+			"vpcid":       r.Vpc.ID,
+			"hwids":       hwids,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v1/types/route"
 	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 )
 
 func Resource() *schema.Resource {
@@ -38,13 +39,16 @@ func Resource() *schema.Resource {
 				Description: "Description of route",
 			},
 			"prefix": {
-				Required:    true,
-				Type:        schema.TypeString,
-				Description: "Route destination to match",
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Route destination to match",
 			},
 			"nexthop": {
 				Required:    true,
 				Type:        schema.TypeString,
+				StateFunc:   ipaddr.StateFunc,
 				ForceNew:    true,
 				Description: "Traffic destined to the Prefix will be routed towards the Next-Hop. Note that static routes will be injected only on units that have the Next-Hop as a connected network",
 			},
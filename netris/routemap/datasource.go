@@ -17,21 +17,31 @@ limitations under the License.
 package routemap
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/netrisai/netriswebapi/v1/types/routemap"
 	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 )
 
 func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Creates and manages BGP Route-maps",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of route-map",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of route-map",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of route-map",
 			},
 		},
 
@@ -46,14 +56,13 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	name := d.Get("name").(string)
-	obj, ok := findByName(name, clientset)
-	if !ok {
-		return fmt.Errorf("Coudn't find routemap '%s'", d.Get("name").(string))
+	obj, err := findRouteMap(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
 	}
 
 	d.SetId(strconv.Itoa(obj.ID))
-	err := d.Set("name", obj.Name)
+	err = d.Set("name", obj.Name)
 	if err != nil {
 		return err
 	}
@@ -61,6 +70,18 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func findRouteMap(clientset *api.Clientset, id, name string) (*routemap.RouteMap, error) {
+	list, err := clientset.RouteMap().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, id, name,
+		func(obj *routemap.RouteMap) int { return obj.ID },
+		func(obj *routemap.RouteMap) string { return obj.Name },
+		"route-map", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	clientset := m.(*api.Clientset)
 	name := d.Get("name").(string)
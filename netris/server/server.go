@@ -25,6 +25,7 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/inventory"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -55,11 +56,13 @@ func Resource() *schema.Resource {
 			},
 			"mainip": {
 				Type:        schema.TypeString,
+				StateFunc:   ipaddr.StateFunc,
 				Optional:    true,
 				Description: "A unique IP address which will be used as a loopback address of this unit. Valid value is ip address (example `198.51.100.11`) or `auto`. If set `auto` the controller will assign an ip address automatically from subnets with relevant purpose.",
 			},
 			"mgmtip": {
 				Type:        schema.TypeString,
+				StateFunc:   ipaddr.StateFunc,
 				Optional:    true,
 				Description: "A unique IP address to be used on out of band management interface. Valid value is ip address (example `192.0.2.11`) or `auto`. If set `auto` the controller will assign an ip address automatically from subnets with relevant purpose.",
 			},
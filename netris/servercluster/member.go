@@ -0,0 +1,260 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servercluster
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/servercluster"
+)
+
+// membersMu serializes changes to server cluster membership. The controller
+// only accepts the full server list, so concurrent read-modify-write cycles
+// from members and the parent cluster would otherwise lose updates.
+var membersMu sync.Mutex
+
+func MemberResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attaches a server to a ServerCluster",
+		Schema: map[string]*schema.Schema{
+			"clusterid": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the ServerCluster.",
+			},
+			"serverid": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the server to attach.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Create: memberCreate,
+		Read:   memberRead,
+		Delete: memberDelete,
+		Exists: memberExists,
+		Importer: &schema.ResourceImporter{
+			State: memberImport,
+		},
+	}
+}
+
+func memberID(clusterID, serverID int) string {
+	return fmt.Sprintf("%d/%d", clusterID, serverID)
+}
+
+func parseMemberID(id string) (clusterID, serverID int, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid servercluster member id '%s', expected clusterid/serverid", id)
+	}
+	clusterID, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid servercluster member id '%s', expected clusterid/serverid", id)
+	}
+	serverID, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid servercluster member id '%s', expected clusterid/serverid", id)
+	}
+	return clusterID, serverID, nil
+}
+
+func hasServer(cluster *servercluster.ServerCluster, serverID int) bool {
+	for _, s := range cluster.Servers {
+		if s.ID == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// updateServers replaces the server list of the cluster, keeping its tags.
+func updateServers(clientset *api.Clientset, cluster *servercluster.ServerCluster, servers []servercluster.Servers) error {
+	sort.Slice(servers, func(i, j int) bool { return servers[i].ID < servers[j].ID })
+
+	reply, err := clientset.ServerCluster().Update(cluster.ID, &servercluster.ServerClusterU{
+		Tags:    cluster.Tags,
+		Servers: servers,
+	})
+	if err != nil {
+		return err
+	}
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", reply.Data)
+	}
+
+	return nil
+}
+
+func memberCreate(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	clusterID := d.Get("clusterid").(int)
+	serverID := d.Get("serverid").(int)
+
+	err := attachServer(clientset, clusterID, serverID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(memberID(clusterID, serverID))
+
+	err = waitForProvisioning(clientset, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func attachServer(clientset *api.Clientset, clusterID, serverID int) error {
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	cluster, err := clientset.ServerCluster().GetByID(clusterID)
+	if err != nil || cluster == nil || cluster.ID == 0 {
+		return fmt.Errorf("couldn't find servercluster with id '%d'", clusterID)
+	}
+
+	if hasServer(cluster, serverID) {
+		return fmt.Errorf("server '%d' is already a member of servercluster '%s'", serverID, cluster.Name)
+	}
+
+	servers := append(cluster.Servers, servercluster.Servers{ID: serverID})
+	log.Printf("[DEBUG] attaching server %d to servercluster %d", serverID, clusterID)
+	return updateServers(clientset, cluster, servers)
+}
+
+func memberRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	clusterID, serverID, err := parseMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.ServerCluster().GetByID(clusterID)
+	if err != nil || cluster == nil || cluster.ID == 0 || !hasServer(cluster, serverID) {
+		d.SetId("")
+		return nil
+	}
+
+	err = d.Set("clusterid", clusterID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("serverid", serverID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func memberDelete(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	clusterID, serverID, err := parseMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	detached, err := detachServer(clientset, clusterID, serverID)
+	if err != nil {
+		return err
+	}
+
+	if detached {
+		err = waitForProvisioning(clientset, clusterID, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// detachServer removes the server from the cluster and reports whether the
+// cluster was changed. A cluster that's already gone is left alone.
+func detachServer(clientset *api.Clientset, clusterID, serverID int) (bool, error) {
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	cluster, err := clientset.ServerCluster().GetByID(clusterID)
+	if err != nil || cluster == nil || cluster.ID == 0 {
+		return false, nil
+	}
+
+	servers := []servercluster.Servers{}
+	for _, s := range cluster.Servers {
+		if s.ID != serverID {
+			servers = append(servers, s)
+		}
+	}
+
+	if len(servers) == len(cluster.Servers) {
+		return false, nil
+	}
+
+	log.Printf("[DEBUG] detaching server %d from servercluster %d", serverID, clusterID)
+	return true, updateServers(clientset, cluster, servers)
+}
+
+func memberExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	clientset := m.(*api.Clientset)
+
+	clusterID, serverID, err := parseMemberID(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	cluster, err := clientset.ServerCluster().GetByID(clusterID)
+	if err != nil {
+		log.Println("[DEBUG] serverclusterMemberExist response err:", err)
+	}
+
+	if cluster == nil || cluster.ID == 0 {
+		return false, nil
+	}
+
+	return hasServer(cluster, serverID), nil
+}
+
+func memberImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clusterID, serverID, err := parseMemberID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(memberID(clusterID, serverID))
+	return []*schema.ResourceData{d}, nil
+}
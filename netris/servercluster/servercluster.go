@@ -22,6 +22,7 @@ import (
 	"log"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/netrisai/netriswebapi/http"
@@ -79,6 +80,10 @@ func Resource() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Create: resourceCreate,
 		Read:   resourceRead,
 		Update: resourceUpdate,
@@ -164,6 +169,12 @@ func resourceCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(strconv.Itoa(idStruct.ID))
+
+	err = waitForProvisioning(clientset, idStruct.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -204,10 +215,16 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	serversList := apiServerCluster.Servers
+	// Only track the servers this resource manages, so that servers attached
+	// with netris_servercluster_member don't show up as drift. An imported
+	// cluster tracks no servers; the servers of its configuration are taken
+	// over on the next apply and the other attached servers are left alone.
+	managed := d.Get("servers").(*schema.Set)
 	servers := []int{}
-	for _, server := range serversList {
-		servers = append(servers, server.ID)
+	for _, server := range apiServerCluster.Servers {
+		if managed.Contains(server.ID) {
+			servers = append(servers, server.ID)
+		}
 	}
 	sort.Ints(servers)
 
@@ -231,9 +248,43 @@ func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 		tags = append(tags, tag.(string))
 	}
 
-	serversList := d.Get("servers").(*schema.Set).List()
+	err := updateCluster(clientset, d, serverclusterID, tags)
+	if err != nil {
+		return err
+	}
+
+	err = waitForProvisioning(clientset, serverclusterID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateCluster sends the update while holding membersMu. Waiting for the
+// provisioning happens outside the lock, so that netris_servercluster_member
+// changes aren't blocked for as long as the provisioning takes.
+func updateCluster(clientset *api.Clientset, d *schema.ResourceData, serverclusterID int, tags []string) error {
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	apiServerCluster, err := clientset.ServerCluster().GetByID(serverclusterID)
+	if err != nil || apiServerCluster == nil || apiServerCluster.ID == 0 {
+		return fmt.Errorf("couldn't find servercluster with id '%d'", serverclusterID)
+	}
+
+	// Keep the servers attached by netris_servercluster_member and only apply
+	// the difference between the old and the new servers of this resource.
+	o, n := d.GetChange("servers")
+	oldServers := o.(*schema.Set)
+	newServers := n.(*schema.Set)
 	servers := []int{}
-	for _, server := range serversList {
+	for _, server := range apiServerCluster.Servers {
+		if !oldServers.Contains(server.ID) && !newServers.Contains(server.ID) {
+			servers = append(servers, server.ID)
+		}
+	}
+	for _, server := range newServers.List() {
 		servers = append(servers, server.(int))
 	}
 
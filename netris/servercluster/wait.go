@@ -0,0 +1,107 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servercluster
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/servercluster"
+)
+
+const (
+	provisioningPending = "pending"
+	provisioningReady   = "ready"
+
+	provisioningPollInterval = 5 * time.Second
+)
+
+// The client library doesn't enumerate the statuses of a cluster, so the
+// ones the controller reports are listed here. Like the Pending and Target
+// lists of a StateChangeConf, anything else stops the wait with an error.
+var (
+	provisioningPendingStatuses = []string{"pending", "provisioning", "in progress", "in_progress", "creating", "updating", "applying"}
+	provisioningReadyStatuses   = []string{"ready", "active", "ok", "success", "succeeded", "done", "complete", "completed", "provisioned"}
+	provisioningFailedStatuses  = []string{"failed", "error", "provisioning failed", "provisioning_failed"}
+)
+
+func containsStatus(list []string, status string) bool {
+	for _, s := range list {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// provisioningState maps the controller's cluster status to pending or
+// ready. A cluster that doesn't report a status yet is pending. Failed and
+// unrecognized statuses are returned as errors.
+func provisioningState(cluster *servercluster.ServerCluster) (string, error) {
+	status := strings.ToLower(strings.TrimSpace(cluster.Status.Value))
+	if status == "" {
+		status = strings.ToLower(strings.TrimSpace(cluster.State))
+	}
+
+	switch {
+	case status == "" || containsStatus(provisioningPendingStatuses, status):
+		return provisioningPending, nil
+	case containsStatus(provisioningReadyStatuses, status):
+		return provisioningReady, nil
+	case containsStatus(provisioningFailedStatuses, status):
+		reason := cluster.Status.Label
+		if reason == "" {
+			reason = status
+		}
+		return "", fmt.Errorf("servercluster '%s' provisioning failed: %s", cluster.Name, reason)
+	}
+
+	return "", fmt.Errorf("unexpected status '%s' of servercluster '%s', expected one of %s", status, cluster.Name,
+		strings.Join(append(append([]string{}, provisioningPendingStatuses...), provisioningReadyStatuses...), ", "))
+}
+
+// waitForProvisioning polls the cluster until the controller has finished
+// creating its VPC and V-Nets from the template.
+func waitForProvisioning(clientset *api.Clientset, id int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		cluster, err := clientset.ServerCluster().GetByID(id)
+		if err != nil {
+			return err
+		}
+		if cluster == nil || cluster.ID == 0 {
+			return fmt.Errorf("couldn't find servercluster with id '%d'", id)
+		}
+
+		log.Printf("[DEBUG] servercluster %d status: %s (%s)", id, cluster.Status.Value, cluster.Status.Label)
+		state, err := provisioningState(cluster)
+		if err != nil {
+			return err
+		}
+		if state == provisioningReady {
+			return nil
+		}
+
+		if time.Now().Add(provisioningPollInterval).After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for servercluster '%s' to be provisioned, last status: %s", timeout, cluster.Name, cluster.Status.Value)
+		}
+		time.Sleep(provisioningPollInterval)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servercluster
+
+import (
+	"testing"
+
+	"github.com/netrisai/netriswebapi/v2/types/servercluster"
+)
+
+func TestProvisioningState(t *testing.T) {
+	cases := []struct {
+		value   string
+		state   string
+		want    string
+		wantErr bool
+	}{
+		{value: "ready", want: provisioningReady},
+		{value: "Active", want: provisioningReady},
+		{value: "ok", want: provisioningReady},
+		{value: "completed", want: provisioningReady},
+		{value: "pending", want: provisioningPending},
+		{value: "Provisioning", want: provisioningPending},
+		{value: "in_progress", want: provisioningPending},
+		{value: "creating", want: provisioningPending},
+		{value: "updating", want: provisioningPending},
+		{value: "applying", want: provisioningPending},
+		{value: "failed", wantErr: true},
+		{value: "Error", wantErr: true},
+		{value: "provisioning_failed", wantErr: true},
+		{state: "creating", want: provisioningPending},
+		{state: "error", wantErr: true},
+		{value: "ready", state: "creating", want: provisioningReady},
+		// No status reported yet.
+		{want: provisioningPending},
+		// Unrecognized statuses aren't taken as success.
+		{value: "something-new", wantErr: true},
+		{value: "provisioning-ready", wantErr: true},
+	}
+
+	for _, c := range cases {
+		cluster := &servercluster.ServerCluster{State: c.state}
+		cluster.Status.Value = c.value
+		state, err := provisioningState(cluster)
+		if (err != nil) != c.wantErr || state != c.want {
+			t.Errorf("provisioningState(value %q, state %q) = %q, %v, want %q, error %t", c.value, c.state, state, err, c.want, c.wantErr)
+		}
+	}
+}
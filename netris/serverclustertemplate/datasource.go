@@ -0,0 +1,145 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverclustertemplate
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/serverclustertemplate"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: ServerClusterTemplates",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the ServerClusterTemplate.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the ServerClusterTemplate.",
+			},
+			"vnets": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Server Cluster VNets Template as a JSON string, in the same format as the `vnets` attribute of the `netris_serverclustertemplate` resource.",
+			},
+			"vnet": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "V-Net definitions of the template.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"postfix": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Postfix appended to the server cluster name to build the V-Net name.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "V-Net type. `l2vpn` or `l3vpn`.",
+						},
+						"vlan": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`tagged` or `untagged`.",
+						},
+						"vlanid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "VLAN ID, or `auto` for automatic assignment.",
+						},
+						"servernics": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Server NICs attached to the V-Net.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"ipv4gateway": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IPv4 gateway with prefix length.",
+						},
+						"ipv6gateway": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IPv6 gateway with prefix length.",
+						},
+					},
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	template, err := findTemplate(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(template.ID))
+	err = d.Set("name", template.Name)
+	if err != nil {
+		return err
+	}
+
+	vnets := stripIDs(template.Vnets)
+	jsonVNETs, err := json.Marshal(vnets)
+	if err != nil {
+		return err
+	}
+	err = d.Set("vnets", string(jsonVNETs))
+	if err != nil {
+		return err
+	}
+	err = d.Set("vnet", flattenVnets(vnets))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func findTemplate(clientset *api.Clientset, id, name string) (*serverclustertemplate.ServerClusterTemplate, error) {
+	templates, err := clientset.ServerClusterTemplate().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(templates, id, name,
+		func(t *serverclustertemplate.ServerClusterTemplate) int { return t.ID },
+		func(t *serverclustertemplate.ServerClusterTemplate) string { return t.Name },
+		"serverclustertemplate", "id")
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/serverclustertemplate"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 )
 
 func Resource() *schema.Resource {
@@ -38,16 +39,79 @@ func Resource() *schema.Resource {
 				Description: "User assigned name of ServerClusterTemplate.",
 			},
 			"vnets": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Server Cluster VNets Template",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"vnets", "vnet"},
+				ValidateFunc:     validateVnetsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Deprecated:       "Use the vnet block instead.",
+				Description:      "Server Cluster VNets Template as a JSON string.",
+			},
+			"vnet": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"vnets", "vnet"},
+				Description:  "V-Net definition. Every server cluster created from the template gets one V-Net per block.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"postfix": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Postfix appended to the server cluster name to build the V-Net name. Must be unique within the template.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateType,
+							Description:  "V-Net type. Possible values: `l2vpn`, `l3vpn`.",
+						},
+						"vlan": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "untagged",
+							ValidateFunc: validateVlan,
+							Description:  "Possible values: `tagged`, `untagged`. Default value is `untagged`.",
+						},
+						"vlanid": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "auto",
+							ValidateFunc: validateVlanID,
+							Description:  "VLAN ID in range 2-4094, or `auto` for automatic assignment. Default value is `auto`.",
+						},
+						"servernics": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Server NICs attached to the V-Net. Example: `[\"eth1\", \"eth2\"]`",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"ipv4gateway": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: ipaddr.ValidateIPv4Prefix,
+							StateFunc:    ipaddr.StateFunc,
+							Description:  "IPv4 gateway with prefix length. Example: `192.168.0.254/24`",
+						},
+						"ipv6gateway": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: ipaddr.ValidateIPv6Prefix,
+							StateFunc:    ipaddr.StateFunc,
+							Description:  "IPv6 gateway with prefix length. Example: `2001:db8::1/64`",
+						},
+					},
+				},
 			},
 		},
-		Create: resourceCreate,
-		Read:   resourceRead,
-		Update: resourceUpdate,
-		Delete: resourceDelete,
-		Exists: resourceExists,
+		CustomizeDiff: customizeDiff,
+		Create:        resourceCreate,
+		Read:          resourceRead,
+		Update:        resourceUpdate,
+		Delete:        resourceDelete,
+		Exists:        resourceExists,
 		Importer: &schema.ResourceImporter{
 			State: resourceImport,
 		},
@@ -58,22 +122,31 @@ func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 	return true
 }
 
+func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	postfixes := make(map[string]bool)
+	for _, raw := range d.Get("vnet").([]interface{}) {
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		postfix := v["postfix"].(string)
+		if postfix == "" {
+			continue
+		}
+		if postfixes[postfix] {
+			return fmt.Errorf("duplicate vnet postfix '%s'", postfix)
+		}
+		postfixes[postfix] = true
+	}
+	return nil
+}
+
 func resourceCreate(d *schema.ResourceData, m interface{}) error {
 	log.Println("[DEBUG] serverClusterTemplateCreate")
 	clientset := m.(*api.Clientset)
-	var vnetsUnmarshaled interface{}
 
-	jsonString := d.Get("vnets").(string)
-	// Convert the JSON string to the interface{}
-	err := json.Unmarshal([]byte(jsonString), &vnetsUnmarshaled)
+	vnetsSlice, err := expandTemplateVnets(d)
 	if err != nil {
-		log.Println("[DEBUG] Error converting JSON string to interface{}: ", err)
-		return err
-	}
-
-	vnetsSlice, ok := vnetsUnmarshaled.([]interface{})
-	if !ok {
-		log.Println("[DEBUG] Expected []interface{} but got something else")
 		return err
 	}
 
@@ -139,22 +212,21 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	netrisVNETs := apiServerClusterTemplate.Vnets
-	for _, item := range netrisVNETs {
-		if itemMap, ok := item.(map[string]interface{}); ok {
-			delete(itemMap, "id")
+	vnets := stripIDs(apiServerClusterTemplate.Vnets)
+	if _, ok := d.GetOk("vnets"); ok {
+		jsonVNETs, err := json.Marshal(vnets)
+		if err != nil {
+			return err
+		}
+		err = d.Set("vnets", string(jsonVNETs))
+		if err != nil {
+			return err
+		}
+	} else {
+		err = d.Set("vnet", flattenVnets(vnets))
+		if err != nil {
+			return err
 		}
-	}
-
-	jsonVNETs, err := json.Marshal(netrisVNETs)
-
-	if err != nil {
-		log.Fatalf("Error marshalling data to JSON: %v", err)
-	}
-
-	err = d.Set("vnets", string(jsonVNETs))
-	if err != nil {
-		return err
 	}
 
 	return nil
@@ -166,20 +238,8 @@ func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 
 	serverClusterTemplateID, _ := strconv.Atoi(d.Id())
 
-	var vnetsUnmarshaled interface{}
-
-	jsonString := d.Get("vnets").(string)
-	// Convert the JSON string to the interface{}
-	err := json.Unmarshal([]byte(jsonString), &vnetsUnmarshaled)
+	vnetsSlice, err := expandTemplateVnets(d)
 	if err != nil {
-		log.Println("[DEBUG] Error converting JSON string to interface{}: ", err)
-		return err
-	}
-
-	// If you need to work with the data, you can use type assertions
-	vnetsSlice, ok := vnetsUnmarshaled.([]interface{})
-	if !ok {
-		log.Println("[DEBUG] Expected []interface{} but got something else")
 		return err
 	}
 
@@ -0,0 +1,155 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverclustertemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// stringValue returns v as a string. The controller returns numbers such as
+// vlanID either quoted or as JSON numbers depending on how they were saved.
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// flattenVnets converts the template's V-Net definitions as returned by the
+// controller into the typed `vnet` list.
+func flattenVnets(vnets []interface{}) []map[string]interface{} {
+	list := make([]map[string]interface{}, 0)
+	for _, item := range vnets {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		nics := []string{}
+		if raw, ok := v["serverNics"].([]interface{}); ok {
+			for _, nic := range raw {
+				nics = append(nics, stringValue(nic))
+			}
+		}
+
+		// The controller omits vlan for untagged V-Nets saved without it.
+		vlan := stringValue(v["vlan"])
+		if vlan == "" {
+			vlan = "untagged"
+		}
+
+		list = append(list, map[string]interface{}{
+			"postfix":     stringValue(v["postfix"]),
+			"type":        stringValue(v["type"]),
+			"vlan":        vlan,
+			"vlanid":      stringValue(v["vlanID"]),
+			"servernics":  nics,
+			"ipv4gateway": stringValue(v["ipv4Gateway"]),
+			"ipv6gateway": stringValue(v["ipv6Gateway"]),
+		})
+	}
+	return list
+}
+
+// stripIDs removes the controller assigned ids from the V-Net definitions so
+// that they match the JSON the user wrote.
+func stripIDs(vnets []interface{}) []interface{} {
+	for _, item := range vnets {
+		if itemMap, ok := item.(map[string]interface{}); ok {
+			delete(itemMap, "id")
+		}
+	}
+	return vnets
+}
+
+// expandVnetsJSON parses the deprecated `vnets` JSON string, which must be a
+// list of objects.
+func expandVnetsJSON(s string) ([]interface{}, error) {
+	var list []interface{}
+	if err := json.Unmarshal([]byte(s), &list); err != nil {
+		return nil, fmt.Errorf("expected a JSON list of V-Net objects: %v", err)
+	}
+	for i, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("expected a JSON list of V-Net objects, element %d is not an object", i)
+		}
+	}
+	return list, nil
+}
+
+// expandVnets converts the typed `vnet` blocks into the definitions the
+// controller expects.
+func expandVnets(vnets []interface{}) []interface{} {
+	list := make([]interface{}, 0, len(vnets))
+	for _, raw := range vnets {
+		v := raw.(map[string]interface{})
+
+		nics := []string{}
+		for _, nic := range v["servernics"].([]interface{}) {
+			nics = append(nics, nic.(string))
+		}
+
+		item := map[string]interface{}{
+			"postfix":    v["postfix"].(string),
+			"type":       v["type"].(string),
+			"vlan":       v["vlan"].(string),
+			"vlanID":     v["vlanid"].(string),
+			"serverNics": nics,
+		}
+		if gw := v["ipv4gateway"].(string); gw != "" {
+			item["ipv4Gateway"] = gw
+		}
+		if gw := v["ipv6gateway"].(string); gw != "" {
+			item["ipv6Gateway"] = gw
+		}
+		list = append(list, item)
+	}
+	return list
+}
+
+// expandTemplateVnets returns the V-Net definitions from whichever of `vnet`
+// and `vnets` is configured.
+func expandTemplateVnets(d *schema.ResourceData) ([]interface{}, error) {
+	if s, ok := d.GetOk("vnets"); ok {
+		return expandVnetsJSON(s.(string))
+	}
+	return expandVnets(d.Get("vnet").([]interface{})), nil
+}
+
+// suppressEquivalentJSON suppresses diffs between JSON documents that only
+// differ in formatting or key order.
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var o, n interface{}
+	if err := json.Unmarshal([]byte(old), &o); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverclustertemplate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestVnetsRoundTrip(t *testing.T) {
+	vnets := []interface{}{
+		map[string]interface{}{
+			"postfix":     "East-West",
+			"type":        "l3vpn",
+			"vlan":        "untagged",
+			"vlanid":      "auto",
+			"servernics":  []interface{}{"eth1", "eth2"},
+			"ipv4gateway": "",
+			"ipv6gateway": "",
+		},
+		map[string]interface{}{
+			"postfix":     "North-South",
+			"type":        "l2vpn",
+			"vlan":        "tagged",
+			"vlanid":      "100",
+			"servernics":  []interface{}{"eth9"},
+			"ipv4gateway": "192.168.0.254/24",
+			"ipv6gateway": "2001:db8::1/64",
+		},
+		map[string]interface{}{
+			"postfix":     "OOB-Management",
+			"type":        "l2vpn",
+			"vlan":        "untagged",
+			"vlanid":      "auto",
+			"servernics":  []interface{}{"eth13"},
+			"ipv4gateway": "192.168.10.254/24",
+			"ipv6gateway": "",
+		},
+	}
+
+	// Send the definitions through JSON, the way they travel to the
+	// controller and back.
+	js, err := json.Marshal(expandVnets(vnets))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var returned []interface{}
+	err = json.Unmarshal(js, &returned)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := flattenVnets(returned)
+	if len(got) != len(vnets) {
+		t.Fatalf("flattenVnets returned %d V-Nets, want %d", len(got), len(vnets))
+	}
+	for i, v := range vnets {
+		want := map[string]interface{}{}
+		for k, val := range v.(map[string]interface{}) {
+			want[k] = val
+		}
+		nics := []string{}
+		for _, nic := range want["servernics"].([]interface{}) {
+			nics = append(nics, nic.(string))
+		}
+		want["servernics"] = nics
+
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("V-Net %d:\nexpected: %#v\ngot:      %#v", i, want, got[i])
+		}
+	}
+}
+
+func TestFlattenVnetsDefaultVlan(t *testing.T) {
+	got := flattenVnets([]interface{}{
+		map[string]interface{}{"postfix": "East-West", "type": "l3vpn", "vlanID": "auto"},
+	})
+	if len(got) != 1 || got[0]["vlan"] != "untagged" {
+		t.Errorf("expected vlan untagged, got %#v", got)
+	}
+}
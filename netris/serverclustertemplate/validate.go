@@ -0,0 +1,57 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverclustertemplate
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func validateType(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !(v == "l2vpn" || v == "l3vpn") {
+		errs = append(errs, fmt.Errorf("'%s' must be l2vpn or l3vpn, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+func validateVlan(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if !(v == "tagged" || v == "untagged") {
+		errs = append(errs, fmt.Errorf("'%s' must be tagged or untagged, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+func validateVlanID(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if v == "auto" {
+		return warns, errs
+	}
+	vlan, err := strconv.Atoi(v)
+	if err != nil || !(vlan >= 2 && vlan <= 4094) {
+		errs = append(errs, fmt.Errorf("'%s' must be auto or in range 2-4094, got: %s", key, v))
+	}
+	return warns, errs
+}
+
+func validateVnetsJSON(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := expandVnetsJSON(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("invalid %s: %v", key, err))
+	}
+	return warns, errs
+}
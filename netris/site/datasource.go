@@ -22,43 +22,130 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/site"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 )
 
+// dataAttributes returns the computed attributes shared by the netris_site
+// data source and the items of netris_sites. Switch fabric credentials are
+// deliberately left out.
+func dataAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"publicasn": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Site public ASN that should be used for external bgp peer configuration",
+		},
+		"rohasn": {
+			Type:       schema.TypeInt,
+			Computed:   true,
+			Deprecated: "ROH (Routing on the Host) is obsolete and no longer used. This field will be removed in a future release.",
+		},
+		"vmasn": {
+			Type:       schema.TypeInt,
+			Computed:   true,
+			Deprecated: "ROH (Routing on the Host) is obsolete and no longer used. This field will be removed in a future release.",
+		},
+		"rohroutingprofile": {
+			Type:       schema.TypeString,
+			Computed:   true,
+			Deprecated: "ROH (Routing on the Host) is obsolete and no longer used. This field will be removed in a future release.",
+		},
+		"sitemesh": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Site to site VPN mode. One of `disabled`, `hub`, `spoke`, `dspoke`",
+		},
+		"acldefaultpolicy": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Default ACL policy. `permit` or `deny`",
+		},
+		"switchfabric": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Switch fabric. One of `equinix_metal`, `phoenixnap_bmc`, `dot1q_trunk`, `netris`",
+		},
+		"switchfabriclocation": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Location of the `equinix_metal` or `phoenixnap_bmc` switch fabric provider. Empty for other fabrics",
+		},
+		"vlanrange": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "VLAN range.",
+		},
+		"vlanrangeautoassign": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The range of VLAN IDs for automatic VLAN assignment.",
+		},
+	}
+}
+
 func DataResource() *schema.Resource {
+	s := dataAttributes()
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+		Description:  "The ID of the site",
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+		Description:  "The name of the site",
+	}
+
 	return &schema.Resource{
 		Description: "Data Source: Sites",
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the site",
-			},
-		},
-		Read:   dataResourceRead,
-		Exists: dataResourceExists,
+		Schema:      s,
+		Read:        dataResourceRead,
+		Exists:      dataResourceExists,
 		Importer: &schema.ResourceImporter{
 			State: resourceImport,
 		},
 	}
 }
 
-func dataResourceRead(d *schema.ResourceData, m interface{}) error {
-	clientset := m.(*api.Clientset)
-	var site *site.Site
-	sites, err := clientset.Site().Get()
-	if err != nil {
-		return err
+func flattenSite(s *site.Site) map[string]interface{} {
+	rohProfile := ""
+	if s.RohProfile != nil {
+		rohProfile = s.RohProfile.Value
 	}
 
-	for _, s := range sites {
-		if s.Name == d.Get("name").(string) {
-			site = s
-			break
+	location := ""
+	if s.SwitchFabricProviders != nil {
+		if s.SwitchFabric == "equinix_metal" && s.SwitchFabricProviders.EquinixMetal != nil {
+			location = s.SwitchFabricProviders.EquinixMetal.Location
+		} else if s.SwitchFabric == "phoenixnap_bmc" && s.SwitchFabricProviders.PhoenixNapBmc != nil {
+			location = s.SwitchFabricProviders.PhoenixNapBmc.Location
 		}
 	}
 
-	if site == nil {
-		return nil
+	return map[string]interface{}{
+		"publicasn":            s.PublicAsn,
+		"rohasn":               s.RohAsn,
+		"vmasn":                s.VMAsn,
+		"rohroutingprofile":    rohProfile,
+		"sitemesh":             s.SiteMesh.Value,
+		"acldefaultpolicy":     s.AclPolicy,
+		"switchfabric":         s.SwitchFabric,
+		"switchfabriclocation": location,
+		"vlanrange":            s.VlanRange,
+		"vlanrangeautoassign":  s.VlanRangeAutoAssign,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	site, err := findSite(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
 	}
 
 	d.SetId(strconv.Itoa(site.ID))
@@ -66,10 +153,28 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
+	for k, v := range flattenSite(site) {
+		err = d.Set(k, v)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+func findSite(clientset *api.Clientset, id, name string) (*site.Site, error) {
+	sites, err := clientset.Site().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(sites, id, name,
+		func(s *site.Site) int { return s.ID },
+		func(s *site.Site) string { return s.Name },
+		"site", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	clientset := m.(*api.Clientset)
 	name := d.Get("name").(string)
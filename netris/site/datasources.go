@@ -0,0 +1,95 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package site
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+func ListDataResource() *schema.Resource {
+	item := dataAttributes()
+	item["id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The ID of the site",
+	}
+	item["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the site",
+	}
+
+	return &schema.Resource{
+		Description: "Data Source: Sites",
+		Schema: map[string]*schema.Schema{
+			"switchfabric": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSwitchFabric,
+				Description:  "Return only sites with this switch fabric. Possible values: `equinix_metal`, `phoenixnap_bmc`, `dot1q_trunk`, `netris`.",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching sites.",
+				Elem: &schema.Resource{
+					Schema: item,
+				},
+			},
+		},
+		Read: listDataResourceRead,
+	}
+}
+
+func listDataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	fabric := d.Get("switchfabric").(string)
+
+	sites, err := clientset.Site().Get()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, s := range sites {
+		if fabric != "" && s.SwitchFabric != fabric {
+			continue
+		}
+
+		item := flattenSite(s)
+		item["id"] = s.ID
+		item["name"] = s.Name
+
+		ids = append(ids, strconv.Itoa(s.ID))
+		items = append(items, item)
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
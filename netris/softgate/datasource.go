@@ -0,0 +1,175 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package softgate
+
+import (
+	"fmt"
+	"strconv"
+
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/inventory"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Softgates",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the softgate",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the softgate",
+			},
+			"tenantid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of tenant. Users of this tenant are permitted to edit this unit.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The site ID where this softgate belongs.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Softgate description.",
+			},
+			"profileid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The inventory profile ID of the softgate.",
+			},
+			"mainip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The loopback address of the softgate.",
+			},
+			"mgmtip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The out of band management address of the softgate.",
+			},
+			"flavor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Softgate's flavor.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Softgate HA's role.",
+			},
+			"tags": {
+				Computed: true,
+				Type:     schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	hw, err := findSoftgate(clientset, d.Get("id").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hw.ID))
+	err = d.Set("name", hw.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("tenantid", hw.Tenant.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("siteid", hw.Site.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("description", hw.Description)
+	if err != nil {
+		return err
+	}
+	err = d.Set("profileid", hw.Profile.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("mainip", hw.MainAddress)
+	if err != nil {
+		return err
+	}
+	err = d.Set("mgmtip", hw.MgmtAddress)
+	if err != nil {
+		return err
+	}
+	err = d.Set("flavor", hw.SGFlavor)
+	if err != nil {
+		return err
+	}
+	err = d.Set("role", hw.SGRole)
+	if err != nil {
+		return err
+	}
+	err = d.Set("tags", hw.Tags)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func findSoftgate(clientset *api.Clientset, id, name string) (*inventory.HW, error) {
+	if id != "" {
+		hwID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid softgate id '%s'", id)
+		}
+		hw, err := clientset.Inventory().GetByID(hwID)
+		if err != nil || hw == nil || hw.ID == 0 || hw.Type != "softgate" {
+			return nil, fmt.Errorf("couldn't find softgate with id '%s'", id)
+		}
+		return hw, nil
+	}
+
+	list, err := clientset.Inventory().GetWithParams(inventory.InventoryGetParams{Type: inventory.HardwareTypeSoftgate})
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, "", name,
+		func(hw *inventory.HW) int { return hw.ID },
+		func(hw *inventory.HW) string { return hw.Name },
+		"softgate", "id")
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	api "github.com/netrisai/netriswebapi/v2"
 
@@ -40,10 +41,12 @@ func Resource() *schema.Resource {
 				Description: "Unique name for current subnet.",
 			},
 			"prefix": {
-				ForceNew:    true,
-				Required:    true,
-				Type:        schema.TypeString,
-				Description: "Unique prefix for subnet, must not overlap with other subnets.",
+				ForceNew:     true,
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Unique prefix for subnet, must not overlap with other subnets.",
 			},
 			"tenantid": {
 				Required:    true,
@@ -62,9 +65,11 @@ func Resource() *schema.Resource {
 				Description: "Describes which kind of service will be able to use this subnet. Possible values: `common`, `loopback`, `management`, `load-balancer`, `nat`, `inactive`",
 			},
 			"defaultgateway": {
-				Optional:    true,
-				Type:        schema.TypeString,
-				Description: "Use when purpose is set to `management`.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: ipaddr.ValidateAddress,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Use when purpose is set to `management`.",
 			},
 			"siteids": {
 				Optional:    true,
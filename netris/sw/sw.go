@@ -26,6 +26,7 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/inventory"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -58,7 +59,7 @@ func Resource() *schema.Resource {
 			"nos": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Switch OS. Possible values: `cumulus_linux`, `sonic`, `ubuntu_switch_dev`",
+				Description: "Switch OS. Must be the `tag` of one of the NOS entries the controller supports, see the `netris_nos` data source. Example: `cumulus_linux`",
 			},
 			"asnumber": {
 				Type:        schema.TypeString,
@@ -72,11 +73,13 @@ func Resource() *schema.Resource {
 			},
 			"mainip": {
 				Type:        schema.TypeString,
+				StateFunc:   ipaddr.StateFunc,
 				Required:    true,
 				Description: "A unique IP address which will be used as a loopback address of this unit. Valid value is ip address (example `198.51.100.21`) or `auto`. If set `auto` the controller will assign an ip address automatically from subnets with relevant purpose.",
 			},
 			"mgmtip": {
 				Type:        schema.TypeString,
+				StateFunc:   ipaddr.StateFunc,
 				Required:    true,
 				Description: "A unique IP address to be used on out of band management interface. Valid value is ip address (example `192.0.2.21`) or `auto`. If set `auto` the controller will assign an ip address automatically from subnets with relevant purpose.",
 			},
@@ -128,9 +131,37 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceImport,
 		},
+		CustomizeDiff: customizeDiff,
 	}
 }
 
+// customizeDiff rejects an unsupported nos at plan time instead of sending an
+// empty NOS to the controller on apply.
+func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("nos") || !d.NewValueKnown("nos") {
+		return nil
+	}
+	_, err := findNOS(m.(*api.Clientset), d.Get("nos").(string))
+	return err
+}
+
+func findNOS(clientset *api.Clientset, tag string) (*inventory.NOS, error) {
+	nosList, err := clientset.Inventory().GetNOS()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	for _, nos := range nosList {
+		if nos.Tag == tag {
+			return nos, nil
+		}
+		tags = append(tags, nos.Tag)
+	}
+
+	return nil, fmt.Errorf("unsupported nos '%s', must be one of: %s", tag, strings.Join(tags, ", "))
+}
+
 func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 	return true
 }
@@ -138,18 +169,11 @@ func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 func resourceCreate(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	nosList, err := clientset.Inventory().GetNOS()
+	nos, err := findNOS(clientset, d.Get("nos").(string))
 	if err != nil {
 		return err
 	}
 
-	nosMap := make(map[string]inventory.NOS)
-	for _, nos := range nosList {
-		nosMap[nos.Tag] = *nos
-	}
-
-	nos := nosMap[d.Get("nos").(string)]
-
 	profileID := d.Get("profileid").(int)
 
 	var asnAny interface{} = d.Get("asnumber").(string)
@@ -173,7 +197,7 @@ func resourceCreate(d *schema.ResourceData, m interface{}) error {
 		Tenant:      inventory.IDName{ID: d.Get("tenantid").(int)},
 		Site:        inventory.IDName{ID: d.Get("siteid").(int)},
 		Description: d.Get("description").(string),
-		Nos:         nos,
+		Nos:         *nos,
 		Asn:         asnAny,
 		Profile:     inventory.IDName{ID: profileID},
 		MainAddress: d.Get("mainip").(string),
@@ -312,18 +336,11 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	nosList, err := clientset.Inventory().GetNOS()
+	nos, err := findNOS(clientset, d.Get("nos").(string))
 	if err != nil {
 		return err
 	}
 
-	nosMap := make(map[string]inventory.NOS)
-	for _, nos := range nosList {
-		nosMap[nos.Tag] = *nos
-	}
-
-	nos := nosMap[d.Get("nos").(string)]
-
 	profileID := d.Get("profileid").(int)
 
 	id, _ := strconv.Atoi(d.Id())
@@ -353,7 +370,7 @@ func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 		Description: d.Get("description").(string),
 		Tenant:      inventory.IDName{ID: d.Get("tenantid").(int)},
 		Site:        inventory.IDName{ID: d.Get("siteid").(int)},
-		Nos:         nos,
+		Nos:         *nos,
 		Asn:         asnAny,
 		Profile:     inventory.IDName{ID: profileID},
 		MainAddress: d.Get("mainip").(string),
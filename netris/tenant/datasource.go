@@ -19,6 +19,9 @@ package tenant
 import (
 	"strconv"
 
+	"github.com/netrisai/netriswebapi/v1/types/tenant"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
 	api "github.com/netrisai/netriswebapi/v2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -28,10 +31,19 @@ func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data Source: Tenants",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the tenant",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the tenant",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the tenant",
 			},
 			"description": {
 				Optional:    true,
@@ -48,30 +60,38 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	tenants, err := clientset.Tenant().Get()
+	tenant, err := findTenant(clientset, d.Get("id").(string), d.Get("name").(string))
 	if err != nil {
 		return err
 	}
 
-	for _, tenant := range tenants {
-		if tenant.Name == d.Get("name").(string) {
-			d.SetId(strconv.Itoa(tenant.ID))
-			err = d.Set("name", tenant.Name)
-			if err != nil {
-				return err
-			}
-			if tenant.Description != "" || d.Get("description").(string) != "" {
-				err = d.Set("description", tenant.Description)
-				if err != nil {
-					return err
-				}
-			}
-			break
+	d.SetId(strconv.Itoa(tenant.ID))
+	err = d.Set("name", tenant.Name)
+	if err != nil {
+		return err
+	}
+	if tenant.Description != "" || d.Get("description").(string) != "" {
+		err = d.Set("description", tenant.Description)
+		if err != nil {
+			return err
 		}
 	}
+
 	return nil
 }
 
+func findTenant(clientset *api.Clientset, id, name string) (*tenant.Tenant, error) {
+	tenants, err := clientset.Tenant().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(tenants, id, name,
+		func(t *tenant.Tenant) int { return t.ID },
+		func(t *tenant.Tenant) string { return t.Name },
+		"tenant", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	clientset := m.(*api.Clientset)
 
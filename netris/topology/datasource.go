@@ -0,0 +1,324 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v2/types/link"
+	"github.com/netrisai/netriswebapi/v2/types/port"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Topology",
+		Schema: map[string]*schema.Schema{
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only links having at least one endpoint in this site.",
+			},
+			"links": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of links.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The link ID.",
+						},
+						"local": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Local endpoint of the link.",
+							Elem:        endpointResource(),
+						},
+						"remote": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Remote endpoint of the link.",
+							Elem:        endpointResource(),
+						},
+						"underlay": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the link uses EVPN/BGP underlay for VXLAN transport. `enabled` or `disabled`.",
+						},
+						"mclag": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the link is an MC-LAG peer link.",
+						},
+					},
+				},
+			},
+			"adjacency": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Neighbors of every switch derived from the links, sorted by switch name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"switchid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The switch ID.",
+						},
+						"switch": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The switch name.",
+						},
+						"neighborids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "IDs of the directly connected switches.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+						"neighbors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Names of the directly connected switches.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"linkcount": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of links terminated on the switch.",
+						},
+					},
+				},
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func endpointResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"switchid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The switch ID.",
+			},
+			"switch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The switch name.",
+			},
+			"portid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The port ID.",
+			},
+			"port": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The port name. Example: `swp1`",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The site ID of the switch.",
+			},
+			"ipv4": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IPv4 address of the endpoint.",
+			},
+			"ipv6": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IPv6 address of the endpoint.",
+			},
+		},
+	}
+}
+
+type endpoint struct {
+	SwitchID int
+	Switch   string
+	PortID   int
+	Port     string
+	SiteID   int
+	IPv4     string
+	IPv6     string
+}
+
+// resolveEndpoint completes a link endpoint with the switch it's on. Links
+// only carry the `port@switch` name, so the switch is taken from the port
+// list, falling back to the name for ports the list doesn't contain.
+func resolveEndpoint(e link.LinkIDName, ports map[int]*port.Port) endpoint {
+	ep := endpoint{
+		PortID: e.ID,
+		IPv4:   e.Ipv4,
+		IPv6:   e.Ipv6,
+	}
+	if p, ok := ports[e.ID]; ok {
+		ep.SwitchID = p.Switch.ID
+		ep.Switch = p.Switch.Name
+		ep.Port = p.Port
+		ep.SiteID = p.Site.ID
+		return ep
+	}
+	parts := strings.SplitN(e.Name, "@", 2)
+	ep.Port = parts[0]
+	if len(parts) == 2 {
+		ep.Switch = parts[1]
+	}
+	return ep
+}
+
+func flattenEndpoint(ep endpoint) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"switchid": ep.SwitchID,
+			"switch":   ep.Switch,
+			"portid":   ep.PortID,
+			"port":     ep.Port,
+			"siteid":   ep.SiteID,
+			"ipv4":     ep.IPv4,
+			"ipv6":     ep.IPv6,
+		},
+	}
+}
+
+type adjacent struct {
+	switchID  int
+	name      string
+	neighbors map[string]int
+	linkCount int
+}
+
+// buildAdjacency derives the neighbors of every switch from the link
+// endpoints. Switches are keyed by name, which is always known.
+func buildAdjacency(pairs [][2]endpoint) []map[string]interface{} {
+	nodes := make(map[string]*adjacent)
+	node := func(ep endpoint) *adjacent {
+		n, ok := nodes[ep.Switch]
+		if !ok {
+			n = &adjacent{switchID: ep.SwitchID, name: ep.Switch, neighbors: make(map[string]int)}
+			nodes[ep.Switch] = n
+		}
+		return n
+	}
+
+	for _, pair := range pairs {
+		local, remote := node(pair[0]), node(pair[1])
+		local.linkCount++
+		remote.linkCount++
+		if local != remote {
+			local.neighbors[remote.name] = remote.switchID
+			remote.neighbors[local.name] = local.switchID
+		}
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		n := nodes[name]
+		neighbors := make([]string, 0, len(n.neighbors))
+		for neighbor := range n.neighbors {
+			neighbors = append(neighbors, neighbor)
+		}
+		sort.Strings(neighbors)
+		neighborIDs := make([]int, 0, len(neighbors))
+		for _, neighbor := range neighbors {
+			neighborIDs = append(neighborIDs, n.neighbors[neighbor])
+		}
+		list = append(list, map[string]interface{}{
+			"switchid":    n.switchID,
+			"switch":      n.name,
+			"neighborids": neighborIDs,
+			"neighbors":   neighbors,
+			"linkcount":   n.linkCount,
+		})
+	}
+
+	return list
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	siteID := d.Get("siteid").(int)
+
+	links, err := clientset.Link().Get()
+	if err != nil {
+		return err
+	}
+
+	portList, err := clientset.Port().Get()
+	if err != nil {
+		return err
+	}
+	ports := make(map[int]*port.Port)
+	for _, p := range portList {
+		ports[p.ID] = p
+	}
+
+	ids := []string{}
+	pairs := [][2]endpoint{}
+	linkList := make([]map[string]interface{}, 0)
+	for _, l := range links {
+		local := resolveEndpoint(l.Local, ports)
+		remote := resolveEndpoint(l.Remote, ports)
+		if siteID > 0 && local.SiteID != siteID && remote.SiteID != siteID {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(l.ID))
+		pairs = append(pairs, [2]endpoint{local, remote})
+		linkList = append(linkList, map[string]interface{}{
+			"id":       l.ID,
+			"local":    flattenEndpoint(local),
+			"remote":   flattenEndpoint(remote),
+			"underlay": l.Underlay,
+			"mclag":    l.MCLagPeerLink != nil,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("links", linkList)
+	if err != nil {
+		return err
+	}
+	err = d.Set("adjacency", buildAdjacency(pairs))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
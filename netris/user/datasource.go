@@ -0,0 +1,204 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"strconv"
+
+	"github.com/netrisai/netriswebapi/v1/types/user"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Users",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username"},
+				Description:  "The ID of the user.",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username"},
+				Description:  "Unique username.",
+			},
+			"fullname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full Name of the user.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The email address of the user.",
+			},
+			"emailcc": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Copies of email notifications are sent to this address.",
+			},
+			"phone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "User’s phone number.",
+			},
+			"company": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Company the user works for.",
+			},
+			"position": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Position within the company.",
+			},
+			"userrole": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of User Role. Empty if the user doesn't use a User Role.",
+			},
+			"userroleid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of User Role. `0` if the user doesn't use a User Role.",
+			},
+			"pgroup": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of Permission Group.",
+			},
+			"pgroupid": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of Permission Group.",
+			},
+			"tenants": {
+				Computed: true,
+				Type:     schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Tenant ID (-1 means 'All tenants')",
+						},
+						"edit": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Edit access for tenant",
+						},
+					},
+				},
+				Description: "List of tenants.",
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	id := d.Get("id").(string)
+	username := d.Get("username").(string)
+
+	users, err := clientset.User().Get()
+	if err != nil {
+		return err
+	}
+
+	u, err := lookup.Find(users, id, username,
+		func(u *user.User) int { return u.ID },
+		func(u *user.User) string { return u.Name },
+		"user", "id")
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(u.ID))
+	err = d.Set("username", u.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("fullname", u.Fullname)
+	if err != nil {
+		return err
+	}
+	err = d.Set("email", u.Email)
+	if err != nil {
+		return err
+	}
+	err = d.Set("emailcc", u.EmailCc)
+	if err != nil {
+		return err
+	}
+	err = d.Set("phone", u.Phone)
+	if err != nil {
+		return err
+	}
+	err = d.Set("company", u.Company)
+	if err != nil {
+		return err
+	}
+	err = d.Set("position", u.Position)
+	if err != nil {
+		return err
+	}
+	err = d.Set("userrole", u.Rolename)
+	if err != nil {
+		return err
+	}
+	err = d.Set("userroleid", u.RoleID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("pgroup", u.PermName)
+	if err != nil {
+		return err
+	}
+	err = d.Set("pgroupid", u.PermID)
+	if err != nil {
+		return err
+	}
+
+	tenantsList := make([]map[string]interface{}, 0)
+	for _, tenant := range u.Tenants {
+		id := tenant.ID
+		if id == 0 {
+			id = -1
+		}
+		tenantsList = append(tenantsList, map[string]interface{}{
+			"id":   id,
+			"edit": tenant.TenantWrite,
+		})
+	}
+	err = d.Set("tenants", tenantsList)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userrole
+
+import (
+	"strconv"
+
+	"github.com/netrisai/netriswebapi/v1/types/userrole"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func DataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: User Roles",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the user role.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the user role.",
+			},
+			"description": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "User Role description",
+			},
+			"pgroup": {
+				Computed:    true,
+				Type:        schema.TypeString,
+				Description: "The name of the role's permission group",
+			},
+			"pgroupid": {
+				Computed:    true,
+				Type:        schema.TypeInt,
+				Description: "The ID of the role's permission group",
+			},
+			"tenantids": {
+				Computed: true,
+				Type:     schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "List of tenant IDs",
+			},
+		},
+		Read: dataResourceRead,
+	}
+}
+
+func dataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	id := d.Get("id").(string)
+	name := d.Get("name").(string)
+
+	roles, err := clientset.UserRole().Get()
+	if err != nil {
+		return err
+	}
+
+	role, err := lookup.Find(roles, id, name,
+		func(r *userrole.UserRole) int { return r.ID },
+		func(r *userrole.UserRole) string { return r.Name },
+		"user role", "id")
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(role.ID))
+	err = d.Set("name", role.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("description", role.Description)
+	if err != nil {
+		return err
+	}
+	err = d.Set("pgroup", role.PermName)
+	if err != nil {
+		return err
+	}
+	err = d.Set("pgroupid", role.PermID)
+	if err != nil {
+		return err
+	}
+
+	tenantIds := []int{}
+	for _, tenant := range role.Tenants {
+		tenantIds = append(tenantIds, tenant.TenantID)
+	}
+	err = d.Set("tenantids", tenantIds)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
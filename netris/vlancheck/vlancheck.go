@@ -0,0 +1,236 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vlancheck validates requested VLAN IDs at plan time, against the
+// VLAN range of the site and against VLANs already in use by V-Nets and BGP
+// sessions on the same switch port. Conflicts are otherwise only reported by
+// the controller halfway through an apply.
+package vlancheck
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v2/types/bgp"
+	"github.com/netrisai/netriswebapi/v2/types/port"
+	"github.com/netrisai/netriswebapi/v2/types/site"
+	"github.com/netrisai/netriswebapi/v2/types/vnet"
+
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// Owner identifies the object being planned, so that its own VLANs are not
+// reported as conflicts. ID is 0 for objects that don't exist yet.
+type Owner struct {
+	Kind string
+	ID   int
+}
+
+// Checker looks objects up on the controller lazily and caches them, so that
+// checking many ports of one resource costs a single request per object type.
+type Checker struct {
+	clientset *api.Clientset
+	sites     map[int]*site.Site
+	ports     []*port.Port
+	vnets     []*vnet.VNet
+	bgps      []*bgp.EBGP
+}
+
+func New(clientset *api.Clientset) *Checker {
+	return &Checker{clientset: clientset, sites: map[int]*site.Site{}}
+}
+
+// parseRange parses a VLAN range such as `2-4094` or `100-199,300`.
+func parseRange(s string) ([][2]int, error) {
+	ranges := [][2]int{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid vlan range '%s'", s)
+		}
+		to := from
+		if len(bounds) == 2 {
+			to, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid vlan range '%s'", s)
+			}
+		}
+		ranges = append(ranges, [2]int{from, to})
+	}
+	return ranges, nil
+}
+
+func inRange(ranges [][2]int, from, to int) bool {
+	for _, r := range ranges {
+		if from >= r[0] && to <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func formatVlans(from, to int) string {
+	if from == to {
+		return fmt.Sprintf("vlan %d", from)
+	}
+	return fmt.Sprintf("vlan range %d-%d", from, to)
+}
+
+// SiteRange returns an error when the VLANs from-to are not entirely inside
+// the vlanrange of the site. Sites without a vlanrange accept any VLAN.
+func (c *Checker) SiteRange(siteID, from, to int) error {
+	if siteID <= 0 || from <= 0 {
+		return nil
+	}
+
+	s, ok := c.sites[siteID]
+	if !ok {
+		var err error
+		s, err = c.clientset.Site().GetByID(siteID)
+		if err != nil {
+			return err
+		}
+		c.sites[siteID] = s
+	}
+	if s == nil || s.VlanRange == "" {
+		return nil
+	}
+
+	ranges, err := parseRange(s.VlanRange)
+	if err != nil {
+		return err
+	}
+	if !inRange(ranges, from, to) {
+		return fmt.Errorf("%s is outside vlanrange '%s' of site '%s'", formatVlans(from, to), s.VlanRange, s.Name)
+	}
+
+	return nil
+}
+
+// PortSite returns the ID of the site the port belongs to, or 0 when the
+// port can't be found. The port is given by ID or by name, e.g. `swp5@my-sw01`.
+func (c *Checker) PortSite(portID int, name string) (int, error) {
+	p, err := c.findPort(portID, name)
+	if err != nil || p == nil {
+		return 0, err
+	}
+	return p.Site.ID, nil
+}
+
+func (c *Checker) findPort(portID int, name string) (*port.Port, error) {
+	if c.ports == nil {
+		ports, err := c.clientset.Port().Get()
+		if err != nil {
+			return nil, err
+		}
+		c.ports = ports
+	}
+
+	for _, p := range c.ports {
+		if (portID > 0 && p.ID == portID) || (portID == 0 && fmt.Sprintf("%s@%s", p.Port, p.Switch.Name) == name) {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// Port returns an error when any of the VLANs from-to is already used on the
+// port by a V-Net or BGP session other than owner. The port is given by ID or
+// by name. Ports that don't exist yet can't carry any VLAN and pass.
+func (c *Checker) Port(portID int, name string, from, to int, owner Owner) error {
+	if from <= 0 {
+		return nil
+	}
+
+	p, err := c.findPort(portID, name)
+	if err != nil || p == nil {
+		return err
+	}
+	portName := fmt.Sprintf("%s@%s", p.Port, p.Switch.Name)
+
+	if c.vnets == nil {
+		vnets, err := c.clientset.VNet().Get()
+		if err != nil {
+			return err
+		}
+		c.vnets = vnets
+	}
+	for _, v := range c.vnets {
+		if owner.Kind == "vnet" && v.ID == owner.ID {
+			continue
+		}
+		for _, vp := range v.Ports {
+			if vp.ID != p.ID {
+				continue
+			}
+			vlan, err := strconv.Atoi(vp.Vlan)
+			if err != nil || vlan <= 1 {
+				continue
+			}
+			if vlan >= from && vlan <= to {
+				return fmt.Errorf("vlan %d on port '%s' is already used by vnet '%s'", vlan, portName, v.Name)
+			}
+		}
+	}
+
+	if c.bgps == nil {
+		bgps, err := c.clientset.BGP().Get()
+		if err != nil {
+			return err
+		}
+		c.bgps = bgps
+	}
+	for _, b := range c.bgps {
+		if owner.Kind == "bgp" && b.ID == owner.ID {
+			continue
+		}
+		if b.Vlan <= 1 || (b.Port.ID != p.ID && b.SwitchPortID != p.ID) {
+			continue
+		}
+		if b.Vlan >= from && b.Vlan <= to {
+			return fmt.Errorf("vlan %d on port '%s' is already used by bgp '%s'", b.Vlan, portName, b.Name)
+		}
+	}
+
+	return nil
+}
+
+// Extension checks the VLAN range of a new port extension, e.g. `10-15`,
+// against the vlanrange of the port's site and the VLANs V-Nets and BGP
+// sessions already use on the port.
+func (c *Checker) Extension(vlanrange string, portID int, name string) error {
+	ranges, err := parseRange(vlanrange)
+	if err != nil || len(ranges) != 1 {
+		return nil
+	}
+	from, to := ranges[0][0], ranges[0][1]
+
+	siteID, err := c.PortSite(portID, name)
+	if err != nil {
+		return err
+	}
+	err = c.SiteRange(siteID, from, to)
+	if err != nil {
+		return err
+	}
+	return c.Port(portID, name, from, to, Owner{})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vlancheck
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    [][2]int
+		wantErr bool
+	}{
+		{in: "2-4094", want: [][2]int{{2, 4094}}},
+		{in: "100-199,300", want: [][2]int{{100, 199}, {300, 300}}},
+		{in: " 100 - 199 , 300 ", want: [][2]int{{100, 199}, {300, 300}}},
+		{in: "100-199,,300,", want: [][2]int{{100, 199}, {300, 300}}},
+		{in: "", want: [][2]int{}},
+		{in: "abc", wantErr: true},
+		{in: "100-", wantErr: true},
+		{in: "100-x", wantErr: true},
+		{in: "100-199,x", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := parseRange(c.in)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseRange(%q) = %v, want error", c.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRange(%q) returned error: %v", c.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseRange(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	cases := []struct {
+		ranges   string
+		from, to int
+		want     bool
+	}{
+		{"2-4094", 2, 2, true},
+		{"2-4094", 4094, 4094, true},
+		{"2-4094", 1, 1, false},
+		{"2-4094", 4095, 4095, false},
+		{"2-4094", 10, 20, true},
+		{"100-199,300", 300, 300, true},
+		{"100-199,300", 150, 160, true},
+		{"100-199,300", 250, 250, false},
+		{"100-199,300", 301, 301, false},
+		// A range spanning two sub-ranges is not inside either of them.
+		{"100-199,300", 150, 300, false},
+		{"100-199,200-299", 150, 250, false},
+	}
+
+	for _, c := range cases {
+		ranges, err := parseRange(c.ranges)
+		if err != nil {
+			t.Fatalf("parseRange(%q) returned error: %v", c.ranges, err)
+		}
+		if got := inRange(ranges, c.from, c.to); got != c.want {
+			t.Errorf("inRange(%q, %d, %d) = %t, want %t", c.ranges, c.from, c.to, got, c.want)
+		}
+	}
+}
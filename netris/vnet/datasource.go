@@ -18,11 +18,14 @@ package vnet
 
 import (
 	"fmt"
+	"log"
 	"net"
 	"strconv"
 
 	"github.com/netrisai/netriswebapi/v2/types/ipam"
 	"github.com/netrisai/netriswebapi/v2/types/vnet"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 	"github.com/netrisai/terraform-provider-netris/netris/subnet"
 
 	api "github.com/netrisai/netriswebapi/v2"
@@ -34,10 +37,19 @@ func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data Source: Vnets",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the vnet.",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the vnet.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the vnet.",
 			},
 			"tenantid": {
 				Computed:    true,
@@ -96,7 +108,7 @@ func DataResource() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"prefix": {
-										ValidateFunc: validateGateway,
+										ValidateFunc: ipaddr.ValidatePrefix,
 										Type:         schema.TypeString,
 										Required:     true,
 										Description:  "The address will be serving as anycast default gateway for selected subnet.",
@@ -112,10 +124,10 @@ func DataResource() *schema.Resource {
 				},
 			},
 			"vpcid": {
-				ForceNew:    true,
+				Computed:    true,
 				Optional:    true,
 				Type:        schema.TypeInt,
-				Description: "ID of VPC.",
+				Description: "ID of VPC. When set, only vnets of this VPC are searched.",
 			},
 			"dhcprelay": {
 				Computed:    true,
@@ -156,26 +168,11 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	name := d.Get("name").(string)
-
-	var VNet *vnet.VNet
-
-	vnets, err := clientset.VNet().Get()
+	VNet, err := findVNet(clientset, d.Get("id").(string), d.Get("name").(string), d.Get("vpcid").(int))
 	if err != nil {
 		return err
 	}
 
-	for _, v := range vnets {
-		if v.Name == name {
-			VNet = v
-			break
-		}
-	}
-
-	if VNet == nil {
-		return fmt.Errorf("coudn't find vnet %s", name)
-	}
-
 	vnet, err := clientset.VNet().GetByID(VNet.ID)
 	if err != nil {
 		return err
@@ -228,6 +225,10 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 			var hosts []*ipam.Host
 			var ok bool
 			subnet := subnet.GetByPrefix(subnets, ipNet.String())
+			if subnet == nil {
+				log.Printf("[WARN] couldn't find IPAM subnet %s of gateway '%s'", ipNet, gateway.Prefix)
+				continue
+			}
 			if hosts, ok = hostsList[subnet.ID]; !ok {
 				var err error
 				hosts, err = clientset.IPAM().GetHosts(subnet.ID)
@@ -288,6 +289,24 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func findVNet(clientset *api.Clientset, id, name string, vpcid int) (*vnet.VNet, error) {
+	var vnets []*vnet.VNet
+	var err error
+	if vpcid > 0 {
+		vnets, err = clientset.VNet().GetByVPC(vpcid)
+	} else {
+		vnets, err = clientset.VNet().Get()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(vnets, id, name,
+		func(v *vnet.VNet) int { return v.ID },
+		func(v *vnet.VNet) string { return v.Name },
+		"vnet", "id or vpcid")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	return true, nil
 }
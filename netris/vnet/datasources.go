@@ -0,0 +1,230 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnet
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v2/types/vnet"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func ListDataResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data Source: Vnets",
+		Schema: map[string]*schema.Schema{
+			"vpcid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only V-Nets of this VPC.",
+			},
+			"tenantid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only V-Nets of this tenant.",
+			},
+			"siteid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return only V-Nets stretched to this site.",
+			},
+			"tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only V-Nets having this tag. Example: `tier=web`",
+			},
+			"nameregex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegexp,
+				Description:  "Return only V-Nets whose name matches this regular expression.",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of matching V-Nets.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The V-Net ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The V-Net name.",
+						},
+						"vlan": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The V-Net VLAN ID.",
+						},
+						"vxlanid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The V-Net VXLAN ID.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "V-Net state.",
+						},
+						"tenantid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of tenant.",
+						},
+						"vpcid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of VPC.",
+						},
+						"sites": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "IDs of the sites the V-Net is stretched to.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+						"gateways": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Gateway addresses of the V-Net with prefix length.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+		Read: listDataResourceRead,
+	}
+}
+
+func hasSite(v *vnet.VNet, siteID int) bool {
+	for _, site := range v.Sites {
+		if site.ID == siteID {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func listDataResourceRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vpcid := d.Get("vpcid").(int)
+	tenantID := d.Get("tenantid").(int)
+	siteID := d.Get("siteid").(int)
+	tag := d.Get("tag").(string)
+
+	var nameRe *regexp.Regexp
+	if r := d.Get("nameregex").(string); r != "" {
+		var err error
+		nameRe, err = regexp.Compile(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	var vnets []*vnet.VNet
+	var err error
+	if vpcid > 0 {
+		vnets, err = clientset.VNet().GetByVPC(vpcid)
+	} else {
+		vnets, err = clientset.VNet().Get()
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	items := make([]map[string]interface{}, 0)
+	for _, v := range vnets {
+		if vpcid > 0 && v.Vpc.ID != vpcid {
+			continue
+		}
+		if tenantID > 0 && v.Tenant.ID != tenantID {
+			continue
+		}
+		if siteID > 0 && !hasSite(v, siteID) {
+			continue
+		}
+		if tag != "" && !hasTag(v.Tags, tag) {
+			continue
+		}
+		if nameRe != nil && !nameRe.MatchString(v.Name) {
+			continue
+		}
+
+		sites := make([]int, 0)
+		for _, site := range v.Sites {
+			sites = append(sites, site.ID)
+		}
+		gateways := make([]string, 0)
+		for _, gateway := range v.Gateways {
+			gateways = append(gateways, gateway.Prefix)
+		}
+
+		ids = append(ids, strconv.Itoa(v.ID))
+		items = append(items, map[string]interface{}{
+			"id":       v.ID,
+			"name":     v.Name,
+			"vlan":     v.Vlan,
+			"vxlanid":  v.VxlanID,
+			"state":    v.State,
+			"tenantid": v.Tenant.ID,
+			"vpcid":    v.Vpc.ID,
+			"sites":    sites,
+			"gateways": gateways,
+			"tags":     v.Tags,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	err = d.Set("items", items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netriswebapi/v2/types/vnet"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func GatewayResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Creates and manages V-Net gateways",
+		Schema: map[string]*schema.Schema{
+			"vnetid": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the V-Net.",
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ipaddr.ValidatePrefix,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "The address will be serving as anycast default gateway for selected subnet. Example: `203.0.113.1/25`",
+			},
+			"vlanid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "VLAN ID of the gateway.",
+			},
+			"dhcp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateDHCP,
+				Description:  "DHCP server for the gateway's subnet. Possible values: `enabled`, `disabled`. Default value is `disabled`.",
+			},
+			"dhcpoptionsetid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the DHCP option set. Applies with or without a DHCP range.",
+			},
+			"dhcpstartip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: ipaddr.ValidateAddress,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "First address of the DHCP range. Example: `203.0.113.10`",
+			},
+			"dhcpendip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: ipaddr.ValidateAddress,
+				StateFunc:    ipaddr.StateFunc,
+				Description:  "Last address of the DHCP range. Example: `203.0.113.100`",
+			},
+		},
+		CustomizeDiff: gatewayCustomizeDiff,
+		Create:        gatewayCreate,
+		Read:          gatewayRead,
+		Update:        gatewayUpdate,
+		Delete:        gatewayDelete,
+		Exists:        gatewayExists,
+		Importer: &schema.ResourceImporter{
+			State: gatewayImport,
+		},
+	}
+}
+
+func gatewayID(vnetID int, prefix string) string {
+	return fmt.Sprintf("%d/%s", vnetID, ipaddr.Canonical(prefix))
+}
+
+func parseGatewayID(id string) (vnetID int, prefix string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) == 2 && parts[1] != "" {
+		vnetID, err = strconv.Atoi(parts[0])
+		if err == nil {
+			return vnetID, parts[1], nil
+		}
+	}
+	return 0, "", fmt.Errorf("invalid vnet gateway id '%s', expected vnetid/prefix", id)
+}
+
+func findGateway(v *vnet.VNetDetailed, prefix string) int {
+	for i, g := range v.Gateways {
+		if ipaddr.Canonical(g.Prefix) == ipaddr.Canonical(prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+func expandGateway(d *schema.ResourceData) vnet.VNetUpdateGateway {
+	gw := vnet.VNetUpdateGateway{
+		Prefix: d.Get("prefix").(string),
+		Vlan:   d.Get("vlanid").(string),
+	}
+	if d.Get("dhcp").(string) == "enabled" {
+		gw.DHCPEnabled = true
+		gw.DHCPLeaseCount = 2
+		// The option set applies with or without a DHCP range.
+		if d.Get("dhcpstartip").(string) != "" || d.Get("dhcpoptionsetid").(int) > 0 {
+			gw.DHCP = &vnet.VNetGatewayDHCP{
+				OptionSet: vnet.IDName{ID: d.Get("dhcpoptionsetid").(int)},
+				Start:     d.Get("dhcpstartip").(string),
+				End:       d.Get("dhcpendip").(string),
+			}
+		}
+	}
+	return gw
+}
+
+func gatewayCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("prefix") {
+		return nil
+	}
+
+	gw := gatewaySpec{
+		prefix: d.Get("prefix").(string),
+		dhcp:   d.Get("dhcp").(string) == "enabled",
+	}
+	if d.NewValueKnown("dhcpstartip") && d.NewValueKnown("dhcpendip") {
+		gw.dhcpStart = d.Get("dhcpstartip").(string)
+		gw.dhcpEnd = d.Get("dhcpendip").(string)
+	}
+	err := checkDHCPRange(gw)
+	if err != nil {
+		return err
+	}
+
+	// The V-Net may not exist yet, in which case its VPC and sites are unknown.
+	// The prefix of an existing gateway can't change, so it's checked once.
+	if d.Id() != "" || !d.NewValueKnown("vnetid") {
+		return nil
+	}
+
+	clientset := m.(*api.Clientset)
+	v, err := clientset.VNet().GetByID(d.Get("vnetid").(int))
+	if err != nil || v == nil || v.ID == 0 {
+		return nil
+	}
+
+	subnets, err := getSubnets(clientset, v.Vpc.ID)
+	if err != nil {
+		return err
+	}
+
+	siteIDs := []int{}
+	for _, site := range v.Sites {
+		siteIDs = append(siteIDs, site.ID)
+	}
+
+	return checkGatewaySubnet(subnets, siteIDs, gw)
+}
+
+func gatewayCreate(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID := d.Get("vnetid").(int)
+	prefix := d.Get("prefix").(string)
+
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		return fmt.Errorf("couldn't find vnet with id '%d'", vnetID)
+	}
+
+	if findGateway(v, prefix) >= 0 {
+		return fmt.Errorf("gateway '%s' already exists in vnet '%s'", prefix, v.Name)
+	}
+
+	vnetUpdate := updateRequest(v, existingPorts(v))
+	vnetUpdate.Gateways = append(vnetUpdate.Gateways, expandGateway(d))
+	err = updateVNet(clientset, vnetID, vnetUpdate)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(gatewayID(vnetID, prefix))
+	return gatewayRead(d, m)
+}
+
+func gatewayRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID, prefix, err := parseGatewayID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	i := findGateway(v, prefix)
+	if i < 0 {
+		d.SetId("")
+		return nil
+	}
+	gw := v.Gateways[i]
+
+	err = d.Set("vnetid", vnetID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("prefix", gw.Prefix)
+	if err != nil {
+		return err
+	}
+	err = d.Set("vlanid", gw.Vlan)
+	if err != nil {
+		return err
+	}
+	dhcp := "disabled"
+	if gw.DHCPEnabled {
+		dhcp = "enabled"
+	}
+	err = d.Set("dhcp", dhcp)
+	if err != nil {
+		return err
+	}
+	if gw.DHCP != nil {
+		err = d.Set("dhcpoptionsetid", gw.DHCP.OptionSet.ID)
+		if err != nil {
+			return err
+		}
+		err = d.Set("dhcpstartip", gw.DHCP.Start)
+		if err != nil {
+			return err
+		}
+		err = d.Set("dhcpendip", gw.DHCP.End)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func gatewayUpdate(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID, prefix, err := parseGatewayID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		return fmt.Errorf("couldn't find vnet with id '%d'", vnetID)
+	}
+
+	i := findGateway(v, prefix)
+	if i < 0 {
+		return fmt.Errorf("couldn't find gateway '%s' in vnet '%s'", prefix, v.Name)
+	}
+
+	vnetUpdate := updateRequest(v, existingPorts(v))
+	vnetUpdate.Gateways[i] = expandGateway(d)
+	return updateVNet(clientset, vnetID, vnetUpdate)
+}
+
+func gatewayDelete(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID, prefix, err := parseGatewayID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	i := findGateway(v, prefix)
+	if i >= 0 {
+		vnetUpdate := updateRequest(v, existingPorts(v))
+		vnetUpdate.Gateways = append(vnetUpdate.Gateways[:i], vnetUpdate.Gateways[i+1:]...)
+		err = updateVNet(clientset, vnetID, vnetUpdate)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func gatewayExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	clientset := m.(*api.Clientset)
+
+	vnetID, prefix, err := parseGatewayID(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	v, _ := clientset.VNet().GetByID(vnetID)
+	if v == nil || v.ID == 0 {
+		return false, nil
+	}
+
+	return findGateway(v, prefix) >= 0, nil
+}
+
+func gatewayImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	vnetID, prefix, err := parseGatewayID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(gatewayID(vnetID, prefix))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,415 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/netrisai/netriswebapi/v2/types/vnet"
+	"github.com/netrisai/terraform-provider-netris/netris/vlancheck"
+
+	api "github.com/netrisai/netriswebapi/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// membersMu serializes changes to V-Net ports. The controller only accepts
+// the full port list, so concurrent read-modify-write cycles from members and
+// the parent V-Net would otherwise lose updates.
+var membersMu sync.Mutex
+
+func MemberResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attaches a port to a V-Net",
+		Schema: map[string]*schema.Schema{
+			"vnetid": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the V-Net.",
+			},
+			"portid": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"portid", "port"},
+				Description:  "Switch port or network interface ID.",
+			},
+			"port": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"portid", "port"},
+				Description:  "Switch port or network interface name. Example: `swp5@my-sw01`",
+			},
+			"vlanid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "VLAN tag for the port. If not specified, the V-Net's VLAN ID is used, or the port is untagged when the V-Net has none.",
+			},
+			"untagged": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Attach the port untagged. Only when the V-Net has a VLAN ID.",
+			},
+		},
+		Create: memberCreate,
+		Read:   memberRead,
+		Delete: memberDelete,
+		Exists: memberExists,
+		Importer: &schema.ResourceImporter{
+			State: memberImport,
+		},
+		CustomizeDiff: memberCustomizeDiff,
+	}
+}
+
+func memberID(vnetID, portID int) string {
+	return fmt.Sprintf("%d/%d", vnetID, portID)
+}
+
+func parseMemberID(id string) (vnetID, portID int, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 2 {
+		vnetID, err = strconv.Atoi(parts[0])
+		if err == nil {
+			portID, err = strconv.Atoi(parts[1])
+			if err == nil {
+				return vnetID, portID, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid vnet member id '%s', expected vnetid/portid", id)
+}
+
+func portName(p vnet.VNetDetailedPort) string {
+	return fmt.Sprintf("%s@%s", p.Port, p.SwitchName)
+}
+
+func findMemberPort(v *vnet.VNetDetailed, portID int, name string) *vnet.VNetDetailedPort {
+	for i, p := range v.Ports {
+		if (portID > 0 && p.ID == portID) || (portID == 0 && portName(p) == name) {
+			return &v.Ports[i]
+		}
+	}
+	return nil
+}
+
+// updateRequest converts the V-Net as returned by the controller into an
+// update request carrying the given ports and everything else unchanged.
+func updateRequest(v *vnet.VNetDetailed, ports []vnet.VNetUpdatePort) *vnet.VNetUpdate {
+	sites := []vnet.VNetUpdateSite{}
+	for _, s := range v.Sites {
+		sites = append(sites, vnet.VNetUpdateSite{ID: s.ID, Name: s.Name})
+	}
+
+	gateways := []vnet.VNetUpdateGateway{}
+	for _, g := range v.Gateways {
+		gateways = append(gateways, vnet.VNetUpdateGateway{
+			DHCP:           g.DHCP,
+			DHCPEnabled:    g.DHCPEnabled,
+			DHCPLeaseCount: g.DHCPLeaseCount,
+			Prefix:         g.Prefix,
+			Vlan:           g.Vlan,
+		})
+	}
+
+	guestTenants := []vnet.VNetUpdateGuestTenant{}
+	for _, t := range v.GuestTenants {
+		guestTenants = append(guestTenants, vnet.VNetUpdateGuestTenant{ID: t.ID, Name: t.Name})
+	}
+
+	var vlan interface{} = 0
+	if v.Vlan > 0 {
+		vlan = strconv.Itoa(v.Vlan)
+	}
+
+	return &vnet.VNetUpdate{
+		Name:         v.Name,
+		GuestTenants: guestTenants,
+		Sites:        sites,
+		State:        v.State,
+		IPFamily:     v.IPFamily,
+		NativeVlan:   v.NativeVlan,
+		Vlans:        v.Vlans,
+		Gateways:     gateways,
+		Ports:        ports,
+		Vlan:         vlan,
+		Tags:         v.Tags,
+		VxlanID:      v.VxlanID,
+		PortTags:     v.PortTags,
+		DhcpRelay:    v.DhcpRelay,
+	}
+}
+
+// existingPort converts a port of the V-Net as returned by the controller
+// into an update request entry that keeps it as it is.
+func existingPort(p vnet.VNetDetailedPort) vnet.VNetUpdatePort {
+	state := p.State.Value
+	if state == "" {
+		state = "active"
+	}
+	return vnet.VNetUpdatePort{
+		Access:     p.Access,
+		AccessMode: p.AccessMode,
+		Untagged:   p.Untagged,
+		ID:         p.ID,
+		Lacp:       p.Lacp,
+		Vlan:       p.Vlan,
+		State:      state,
+	}
+}
+
+func existingPorts(v *vnet.VNetDetailed) []vnet.VNetUpdatePort {
+	ports := []vnet.VNetUpdatePort{}
+	for _, p := range v.Ports {
+		ports = append(ports, existingPort(p))
+	}
+	return ports
+}
+
+func updateVNet(clientset *api.Clientset, id int, vnetUpdate *vnet.VNetUpdate) error {
+	js, _ := json.Marshal(vnetUpdate)
+	log.Println("[DEBUG]", string(js))
+
+	reply, err := clientset.VNet().Update(id, vnetUpdate)
+	if err != nil {
+		log.Println("[DEBUG]", err)
+		return err
+	}
+
+	if reply.StatusCode != 200 {
+		return fmt.Errorf("%s", reply.Data)
+	}
+
+	return nil
+}
+
+// memberCustomizeDiff checks an explicitly tagged VLAN against the site's
+// vlanrange and the other V-Nets and BGP sessions on the port.
+func memberCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("vnetid") || !d.NewValueKnown("vlanid") {
+		return nil
+	}
+
+	vlan, _ := strconv.Atoi(d.Get("vlanid").(string))
+	if vlan <= 1 {
+		return nil
+	}
+	vnetID := d.Get("vnetid").(int)
+
+	// Only one of portid and port is set, the other one is computed.
+	portID := 0
+	if d.NewValueKnown("portid") {
+		portID = d.Get("portid").(int)
+	}
+	name := ""
+	if d.NewValueKnown("port") {
+		name = d.Get("port").(string)
+	}
+	if portID == 0 && name == "" {
+		return nil
+	}
+
+	checker := vlancheck.New(m.(*api.Clientset))
+	siteID, err := checker.PortSite(portID, name)
+	if err != nil {
+		return err
+	}
+	err = checker.SiteRange(siteID, vlan, vlan)
+	if err != nil {
+		return err
+	}
+	return checker.Port(portID, name, vlan, vlan, vlancheck.Owner{Kind: "vnet", ID: vnetID})
+}
+
+func memberCreate(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID := d.Get("vnetid").(int)
+	portID := d.Get("portid").(int)
+	name := d.Get("port").(string)
+
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		return fmt.Errorf("couldn't find vnet with id '%d'", vnetID)
+	}
+
+	if findMemberPort(v, portID, name) != nil {
+		port := name
+		if portID > 0 {
+			port = strconv.Itoa(portID)
+		}
+		return fmt.Errorf("port '%s' is already a member of vnet '%s'", port, v.Name)
+	}
+
+	vlan := d.Get("vlanid").(string)
+	if vlan == "" {
+		vlan = "1"
+		if v.Vlan > 0 {
+			vlan = strconv.Itoa(v.Vlan)
+		}
+	}
+	untagged := d.Get("untagged").(bool) || vlan == "1"
+
+	ports := append(existingPorts(v), vnet.VNetUpdatePort{
+		AccessMode: untagged,
+		Name:       name,
+		ID:         portID,
+		Vlan:       vlan,
+		State:      "active",
+	})
+
+	err = updateVNet(clientset, vnetID, updateRequest(v, ports))
+	if err != nil {
+		return err
+	}
+
+	if portID == 0 {
+		v, err = clientset.VNet().GetByID(vnetID)
+		if err != nil {
+			return err
+		}
+		p := findMemberPort(v, 0, name)
+		if p == nil {
+			return fmt.Errorf("couldn't find port '%s' in vnet '%s' after attaching it", name, v.Name)
+		}
+		portID = p.ID
+	}
+
+	d.SetId(memberID(vnetID, portID))
+	return memberRead(d, m)
+}
+
+func memberRead(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID, portID, err := parseMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	p := findMemberPort(v, portID, "")
+	if p == nil {
+		d.SetId("")
+		return nil
+	}
+
+	err = d.Set("vnetid", vnetID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("portid", p.ID)
+	if err != nil {
+		return err
+	}
+	err = d.Set("port", portName(*p))
+	if err != nil {
+		return err
+	}
+	err = d.Set("vlanid", p.Vlan)
+	if err != nil {
+		return err
+	}
+	err = d.Set("untagged", p.Untagged)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func memberDelete(d *schema.ResourceData, m interface{}) error {
+	clientset := m.(*api.Clientset)
+
+	vnetID, portID, err := parseMemberID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
+	v, err := clientset.VNet().GetByID(vnetID)
+	if err != nil || v == nil || v.ID == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	ports := []vnet.VNetUpdatePort{}
+	for _, p := range v.Ports {
+		if p.ID != portID {
+			ports = append(ports, existingPort(p))
+		}
+	}
+
+	if len(ports) != len(v.Ports) {
+		err = updateVNet(clientset, vnetID, updateRequest(v, ports))
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func memberExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	clientset := m.(*api.Clientset)
+
+	vnetID, portID, err := parseMemberID(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	v, _ := clientset.VNet().GetByID(vnetID)
+	if v == nil || v.ID == 0 {
+		return false, nil
+	}
+
+	return findMemberPort(v, portID, "") != nil, nil
+}
+
+func memberImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	vnetID, portID, err := parseMemberID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(memberID(vnetID, portID))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnet
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
+	"github.com/netrisai/terraform-provider-netris/netris/subnet"
+
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// gatewaySpec is a gateway as configured, either in a sites block of
+// netris_vnet or in netris_vnet_gateway.
+type gatewaySpec struct {
+	prefix    string
+	dhcp      bool
+	dhcpStart string
+	dhcpEnd   string
+}
+
+// checkDHCPRange verifies that the DHCP range lies inside the gateway's
+// subnet, is ordered and doesn't contain the gateway address itself.
+func checkDHCPRange(gw gatewaySpec) error {
+	if !gw.dhcp || gw.dhcpStart == "" || gw.dhcpEnd == "" {
+		return nil
+	}
+
+	prefix, err := ipaddr.ParsePrefix(gw.prefix)
+	if err != nil {
+		return fmt.Errorf("invalid gateway '%s'", gw.prefix)
+	}
+	start, err := ipaddr.ParseAddr(gw.dhcpStart)
+	if err != nil {
+		return fmt.Errorf("gateway '%s': invalid dhcpstartip '%s'", gw.prefix, gw.dhcpStart)
+	}
+	end, err := ipaddr.ParseAddr(gw.dhcpEnd)
+	if err != nil {
+		return fmt.Errorf("gateway '%s': invalid dhcpendip '%s'", gw.prefix, gw.dhcpEnd)
+	}
+
+	network := prefix.Masked()
+	if !network.Contains(start) || !network.Contains(end) {
+		return fmt.Errorf("gateway '%s': DHCP range %s-%s must be inside %s", gw.prefix, start, end, network)
+	}
+	if end.Less(start) {
+		return fmt.Errorf("gateway '%s': dhcpstartip %s must not be after dhcpendip %s", gw.prefix, start, end)
+	}
+	if gwAddr := prefix.Addr(); !gwAddr.Less(start) && !end.Less(gwAddr) {
+		return fmt.Errorf("gateway '%s': DHCP range %s-%s must not include the gateway address", gw.prefix, start, end)
+	}
+
+	return nil
+}
+
+// checkGatewaySubnet verifies that the gateway's subnet is a `common` IPAM
+// subnet available in one of the given sites. A gateway without a matching
+// subnet is only logged, as the subnet may be created in the same apply.
+func checkGatewaySubnet(subnets []*ipam.IPAM, siteIDs []int, gw gatewaySpec) error {
+	prefix, err := ipaddr.ParsePrefix(gw.prefix)
+	if err != nil {
+		return fmt.Errorf("invalid gateway '%s'", gw.prefix)
+	}
+
+	s := subnet.GetByPrefix(subnets, prefix.Masked().String())
+	if s == nil {
+		log.Printf("[WARN] couldn't find IPAM subnet %s for gateway '%s', assuming it's created in the same apply", prefix.Masked(), gw.prefix)
+		return nil
+	}
+
+	if s.Purpose != "common" {
+		return fmt.Errorf("gateway '%s' belongs to subnet '%s' with purpose '%s', must be common", gw.prefix, s.Name, s.Purpose)
+	}
+
+	if len(siteIDs) > 0 && len(s.Sites) > 0 {
+		found := false
+		for _, site := range s.Sites {
+			for _, id := range siteIDs {
+				if site.ID == id {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("gateway '%s' belongs to subnet '%s', which isn't available in the V-Net's sites", gw.prefix, s.Name)
+		}
+	}
+
+	return nil
+}
+
+func getSubnets(clientset *api.Clientset, vpcID int) ([]*ipam.IPAM, error) {
+	if vpcID > 0 {
+		return clientset.IPAM().GetSubnetsByVPC(vpcID)
+	}
+	return clientset.IPAM().GetSubnets()
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnet
+
+import (
+	"testing"
+
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/netriswebapi/v2/types/vnet"
+)
+
+func TestCheckDHCPRange(t *testing.T) {
+	cases := []struct {
+		name string
+		gw   gatewaySpec
+		err  bool
+	}{
+		{
+			name: "inside the subnet",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true, dhcpStart: "192.0.2.10", dhcpEnd: "192.0.2.100"},
+		},
+		{
+			name: "single address",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true, dhcpStart: "192.0.2.10", dhcpEnd: "192.0.2.10"},
+		},
+		{
+			name: "IPv6",
+			gw:   gatewaySpec{prefix: "2001:db8::1/64", dhcp: true, dhcpStart: "2001:db8::10", dhcpEnd: "2001:db8::ff"},
+		},
+		{
+			name: "dhcp disabled is not checked",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcpStart: "198.51.100.10", dhcpEnd: "198.51.100.20"},
+		},
+		{
+			name: "no range is not checked",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true},
+		},
+		{
+			name: "start outside the subnet",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true, dhcpStart: "192.0.1.10", dhcpEnd: "192.0.2.100"},
+			err:  true,
+		},
+		{
+			name: "end outside the subnet",
+			gw:   gatewaySpec{prefix: "192.0.2.1/25", dhcp: true, dhcpStart: "192.0.2.10", dhcpEnd: "192.0.2.200"},
+			err:  true,
+		},
+		{
+			name: "other address family",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true, dhcpStart: "2001:db8::10", dhcpEnd: "2001:db8::20"},
+			err:  true,
+		},
+		{
+			name: "reversed",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true, dhcpStart: "192.0.2.100", dhcpEnd: "192.0.2.10"},
+			err:  true,
+		},
+		{
+			name: "includes the gateway",
+			gw:   gatewaySpec{prefix: "192.0.2.50/24", dhcp: true, dhcpStart: "192.0.2.10", dhcpEnd: "192.0.2.100"},
+			err:  true,
+		},
+		{
+			name: "starts at the gateway",
+			gw:   gatewaySpec{prefix: "192.0.2.10/24", dhcp: true, dhcpStart: "192.0.2.10", dhcpEnd: "192.0.2.100"},
+			err:  true,
+		},
+		{
+			name: "invalid address",
+			gw:   gatewaySpec{prefix: "192.0.2.1/24", dhcp: true, dhcpStart: "192.0.2.300", dhcpEnd: "192.0.2.100"},
+			err:  true,
+		},
+		{
+			name: "zoned address",
+			gw:   gatewaySpec{prefix: "fe80::1/64", dhcp: true, dhcpStart: "fe80::10%eth0", dhcpEnd: "fe80::20"},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		err := checkDHCPRange(c.gw)
+		if (err != nil) != c.err {
+			t.Errorf("%s: checkDHCPRange() error = %v, want error %t", c.name, err, c.err)
+		}
+	}
+}
+
+func TestCheckGatewaySubnet(t *testing.T) {
+	subnets := []*ipam.IPAM{
+		{
+			Type:   "allocation",
+			Prefix: "192.0.2.0/24",
+			Children: []*ipam.IPAM{
+				{Type: "subnet", Name: "common", Prefix: "192.0.2.0/25", Purpose: "common", Sites: []ipam.IDName{{ID: 1}}},
+				{Type: "subnet", Name: "lb", Prefix: "192.0.2.128/26", Purpose: "load-balancer", Sites: []ipam.IDName{{ID: 1}}},
+				{Type: "subnet", Name: "any-site", Prefix: "192.0.2.192/26", Purpose: "common"},
+			},
+		},
+		{
+			Type:   "allocation",
+			Prefix: "2001:db8::/32",
+			Children: []*ipam.IPAM{
+				{Type: "subnet", Name: "wide", Prefix: "2001:db8::/48", Purpose: "management"},
+				{Type: "subnet", Name: "narrow", Prefix: "2001:db8::/64", Purpose: "common"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		siteIDs []int
+		prefix  string
+		err     bool
+	}{
+		{name: "inside a common subnet", siteIDs: []int{1}, prefix: "192.0.2.1/25"},
+		{name: "subnet without sites", siteIDs: []int{2}, prefix: "192.0.2.193/26"},
+		{name: "no sites given", prefix: "192.0.2.1/25"},
+		{name: "prefix length selects the subnet", prefix: "2001:db8::1/64"},
+		{name: "other prefix length", prefix: "2001:db8::1/48", err: true},
+		{name: "wrong purpose", siteIDs: []int{1}, prefix: "192.0.2.129/26", err: true},
+		{name: "wrong site", siteIDs: []int{2}, prefix: "192.0.2.1/25", err: true},
+		// The subnet may be created in the same apply.
+		{name: "only inside an allocation", prefix: "2001:db8:1::1/64"},
+		{name: "outside every subnet", prefix: "198.51.100.1/24"},
+		{name: "inside a subnet with another length", siteIDs: []int{1}, prefix: "192.0.2.1/24"},
+		{name: "invalid prefix", prefix: "192.0.2.1", err: true},
+	}
+
+	for _, c := range cases {
+		err := checkGatewaySubnet(subnets, c.siteIDs, gatewaySpec{prefix: c.prefix})
+		if (err != nil) != c.err {
+			t.Errorf("%s: checkGatewaySubnet() error = %v, want error %t", c.name, err, c.err)
+		}
+	}
+}
+
+func TestExistingPort(t *testing.T) {
+	p := vnet.VNetDetailedPort{
+		ID:         7,
+		Access:     true,
+		AccessMode: true,
+		Lacp:       "on",
+		Vlan:       "100",
+	}
+	p.State.Value = "disabled"
+
+	got := existingPort(p)
+	want := vnet.VNetUpdatePort{ID: 7, Access: true, AccessMode: true, Lacp: "on", Vlan: "100", State: "disabled"}
+	if got != want {
+		t.Errorf("existingPort() = %+v, want %+v", got, want)
+	}
+
+	p.State.Value = ""
+	if got := existingPort(p); got.State != "active" {
+		t.Errorf("existingPort() state = %q, want %q", got.State, "active")
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/netrisai/netriswebapi/http"
 	"github.com/netrisai/netriswebapi/v2/types/ipam"
 	"github.com/netrisai/netriswebapi/v2/types/vnet"
+	"github.com/netrisai/terraform-provider-netris/netris/ipaddr"
 	"github.com/netrisai/terraform-provider-netris/netris/subnet"
+	"github.com/netrisai/terraform-provider-netris/netris/vlancheck"
 
 	api "github.com/netrisai/netriswebapi/v2"
 
@@ -34,7 +36,7 @@ import (
 )
 
 func Resource() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Description: "Creates and manages Vnets",
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -184,7 +186,8 @@ func Resource() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"prefix": {
-										ValidateFunc: validateGateway,
+										ValidateFunc: ipaddr.ValidatePrefix,
+										StateFunc:    ipaddr.StateFunc,
 										Type:         schema.TypeString,
 										Required:     true,
 										Description:  "The address will be serving as anycast default gateway for selected subnet. Example: `203.0.113.1/25`",
@@ -205,13 +208,15 @@ func Resource() *schema.Resource {
 										Computed: true,
 									},
 									"dhcpstartip": {
-										ValidateFunc: validateGateway,
+										ValidateFunc: ipaddr.ValidateAddress,
+										StateFunc:    ipaddr.StateFunc,
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
 									},
 									"dhcpendip": {
-										ValidateFunc: validateGateway,
+										ValidateFunc: ipaddr.ValidateAddress,
+										StateFunc:    ipaddr.StateFunc,
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
@@ -262,14 +267,18 @@ func Resource() *schema.Resource {
 							Description: "ID of the VPC where the DHCP Relay servers reside.",
 						},
 						"primaryaddr": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Primary DHCP Relay address.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: ipaddr.ValidateAddress,
+							StateFunc:    ipaddr.StateFunc,
+							Description:  "Primary DHCP Relay address.",
 						},
 						"secondaryaddr": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Secondary DHCP Relay address.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: ipaddr.ValidateAddress,
+							StateFunc:    ipaddr.StateFunc,
+							Description:  "Secondary DHCP Relay address.",
 						},
 					},
 				},
@@ -285,6 +294,12 @@ func Resource() *schema.Resource {
 		},
 		CustomizeDiff: customizeDiff,
 	}
+
+	// Hash addresses in canonical form, so that spelling them differently
+	// than the controller doesn't replace the set element.
+	gateways := r.Schema["sites"].Elem.(*schema.Resource).Schema["gateways"]
+	gateways.Set = ipaddr.HashResource(gateways.Elem.(*schema.Resource), "prefix", "dhcpstartip", "dhcpendip")
+	return r
 }
 
 // strVal dereferences a *string, returning "" for a nil pointer.
@@ -302,6 +317,15 @@ func strVal(s *string) string {
 // left in the configuration - even with stale vpcid/addresses - does not
 // produce a perpetual diff. dhcprelay is Computed so SetNew is permitted on it.
 func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	err := checkGateways(d, m)
+	if err != nil {
+		return err
+	}
+	err = checkVlans(d, m)
+	if err != nil {
+		return err
+	}
+
 	relays := d.Get("dhcprelay").([]interface{})
 	if len(relays) == 0 || relays[0] == nil {
 		return nil
@@ -320,6 +344,132 @@ func customizeDiff(d *schema.ResourceDiff, m interface{}) error {
 	return nil
 }
 
+// checkGateways resolves every gateway against the IPAM subnets of the
+// V-Net's VPC, so that gateways the controller would reject fail at plan
+// time instead of halfway through an apply. Unchanged V-Nets are left alone.
+func checkGateways(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("sites") {
+		return nil
+	}
+	if !d.NewValueKnown("sites") {
+		return nil
+	}
+
+	type siteGateway struct {
+		siteID int
+		gw     gatewaySpec
+	}
+	gateways := []siteGateway{}
+	for _, raw := range d.Get("sites").([]interface{}) {
+		site, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		gws, ok := site["gateways"].(*schema.Set)
+		if !ok {
+			continue
+		}
+		for _, g := range gws.List() {
+			gateway := g.(map[string]interface{})
+			gw := gatewaySpec{
+				prefix:    gateway["prefix"].(string),
+				dhcp:      gateway["dhcp"].(string) == "enabled",
+				dhcpStart: gateway["dhcpstartip"].(string),
+				dhcpEnd:   gateway["dhcpendip"].(string),
+			}
+			if gw.prefix == "" {
+				continue
+			}
+			err := checkDHCPRange(gw)
+			if err != nil {
+				return err
+			}
+			gateways = append(gateways, siteGateway{site["id"].(int), gw})
+		}
+	}
+
+	if len(gateways) == 0 || !d.NewValueKnown("vpcid") {
+		return nil
+	}
+
+	clientset := m.(*api.Clientset)
+	subnets, err := getSubnets(clientset, d.Get("vpcid").(int))
+	if err != nil {
+		return err
+	}
+
+	for _, g := range gateways {
+		siteIDs := []int{}
+		if g.siteID > 0 {
+			siteIDs = append(siteIDs, g.siteID)
+		}
+		err = checkGatewaySubnet(subnets, siteIDs, g.gw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkVlans verifies the global VLAN ID and the VLAN of every port against
+// the vlanrange of the site and against VLANs other V-Nets and BGP sessions
+// already use on the same port. Unchanged V-Nets are left alone, so that a
+// narrowed vlanrange or a later BGP session doesn't block their plans.
+func checkVlans(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("sites") && !d.HasChange("vlanid") {
+		return nil
+	}
+	if !d.NewValueKnown("sites") || !d.NewValueKnown("vlanid") {
+		return nil
+	}
+
+	vlanid := d.Get("vlanid").(string)
+	globalVlan, _ := strconv.Atoi(vlanid)
+	id, _ := strconv.Atoi(d.Id())
+	owner := vlancheck.Owner{Kind: "vnet", ID: id}
+
+	checker := vlancheck.New(m.(*api.Clientset))
+	for _, raw := range d.Get("sites").([]interface{}) {
+		site, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		siteID := site["id"].(int)
+		err := checker.SiteRange(siteID, globalVlan, globalVlan)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range []string{"interface", "ports"} {
+			p, ok := site[key].(*schema.Set)
+			if !ok {
+				continue
+			}
+			for _, raw := range p.List() {
+				port := raw.(map[string]interface{})
+				vlan := globalVlan
+				if v := port["vlanid"].(string); v != "1" || vlanid == "" {
+					vlan, _ = strconv.Atoi(v)
+				}
+				if vlan <= 1 {
+					continue
+				}
+				err = checker.SiteRange(siteID, vlan, vlan)
+				if err != nil {
+					return err
+				}
+				err = checker.Port(port["id"].(int), port["name"].(string), vlan, vlan, owner)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 	return true
 }
@@ -349,6 +499,58 @@ func getDhcpRelay(d *schema.ResourceData) *vnet.VNetDhcpRelay {
 	}
 }
 
+// configuredPorts returns the names and IDs of the ports and interfaces
+// listed in the sites blocks.
+func configuredPorts(sites []interface{}) (names map[string]bool, ids map[int]bool) {
+	names = make(map[string]bool)
+	ids = make(map[int]bool)
+	for _, s := range sites {
+		site, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"interface", "ports"} {
+			p, ok := site[key].(*schema.Set)
+			if !ok {
+				continue
+			}
+			for _, raw := range p.List() {
+				port := raw.(map[string]interface{})
+				if name := port["name"].(string); name != "" {
+					names[name] = true
+				}
+				if id := port["id"].(int); id > 0 {
+					ids[id] = true
+				}
+			}
+		}
+	}
+	return names, ids
+}
+
+// configuredGateways returns the prefixes of the gateways listed in the
+// sites blocks, in canonical form.
+func configuredGateways(sites []interface{}) map[string]bool {
+	prefixes := make(map[string]bool)
+	for _, s := range sites {
+		site, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if gws, ok := site["gateways"].(*schema.Set); ok {
+			for _, raw := range gws.List() {
+				prefixes[ipaddr.Canonical(raw.(map[string]interface{})["prefix"].(string))] = true
+			}
+		}
+	}
+	return prefixes
+}
+
+func oldSitesList(d *schema.ResourceData) []interface{} {
+	o, _ := d.GetChange("sites")
+	return o.([]interface{})
+}
+
 func resourceCreate(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
@@ -587,6 +789,7 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 	hostsList := make(map[int][]*ipam.Host)
 
 	sitesT := d.Get("sites").([]interface{})
+	importing := len(sitesT) == 0
 	var sitesList []map[string]interface{}
 	for _, site := range sitesT {
 		sitesList = append(sitesList, site.(map[string]interface{}))
@@ -652,6 +855,17 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 			if port.Site.ID == site.ID {
 				m := make(map[string]interface{})
 				name := fmt.Sprintf("%s@%s", port.Port, port.SwitchName)
+				// Ports attached with netris_vnet_member or outside of
+				// Terraform aren't owned by this resource. An imported
+				// V-Net has no sites in state yet and takes all of them.
+				_, byName := tportVlanIDMap[name]
+				_, byID := tportPortID[port.ID]
+				if !byName && !byID {
+					if !importing {
+						continue
+					}
+					m["name"] = name
+				}
 				if vlanFromTf, ok := tportVlanIDMap[name]; ok {
 					m["name"] = name
 					if vlanFromTf == "1" && vnetresp.Vlan != 0 && d.Get("vlanid").(string) != "" {
@@ -697,6 +911,10 @@ func resourceRead(d *schema.ResourceData, m interface{}) error {
 			var hosts []*ipam.Host
 			var ok bool
 			subnet := subnet.GetByPrefix(subnets, ipNet.String())
+			if subnet == nil {
+				log.Printf("[WARN] couldn't find IPAM subnet %s of gateway '%s'", ipNet, gateway.Prefix)
+				continue
+			}
 			if hosts, ok = hostsList[subnet.ID]; !ok {
 				var err error
 				hosts, err = clientset.IPAM().GetHosts(subnet.ID)
@@ -828,6 +1046,9 @@ func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 		sitesList = append(sitesList, site.(map[string]interface{}))
 	}
 
+	membersMu.Lock()
+	defer membersMu.Unlock()
+
 	id, _ := strconv.Atoi(d.Id())
 	v, err := clientset.VNet().GetByID(id)
 	if err != nil {
@@ -986,6 +1207,34 @@ func resourceUpdate(d *schema.ResourceData, m interface{}) error {
 		members = newMembers
 	}
 
+	// Keep the gateways and ports this resource has never owned, such as the
+	// ones managed with netris_vnet_gateway and netris_vnet_member.
+	oldGateways := configuredGateways(oldSitesList(d))
+	newGateways := configuredGateways(sites)
+	for _, g := range v.Gateways {
+		prefix := ipaddr.Canonical(g.Prefix)
+		if oldGateways[prefix] || newGateways[prefix] {
+			continue
+		}
+		gatewayList = append(gatewayList, vnet.VNetUpdateGateway{
+			DHCP:           g.DHCP,
+			DHCPEnabled:    g.DHCPEnabled,
+			DHCPLeaseCount: g.DHCPLeaseCount,
+			Prefix:         g.Prefix,
+			Vlan:           g.Vlan,
+		})
+	}
+
+	oldNames, oldIDs := configuredPorts(oldSitesList(d))
+	newNames, newIDs := configuredPorts(sites)
+	for _, p := range v.Ports {
+		name := portName(p)
+		if oldNames[name] || oldIDs[p.ID] || newNames[name] || newIDs[p.ID] {
+			continue
+		}
+		members = append(members, existingPort(p))
+	}
+
 	var vlanidInterface any
 
 	if vnetTypeOne {
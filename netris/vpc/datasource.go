@@ -17,23 +17,32 @@ limitations under the License.
 package vpc
 
 import (
-	"fmt"
 	"log"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	api "github.com/netrisai/netriswebapi/v2"
 	"github.com/netrisai/netriswebapi/v2/types/vpc"
+	"github.com/netrisai/terraform-provider-netris/netris/lookup"
 )
 
 func DataResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data Source: VPC",
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the vpc",
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the vpc",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the vpc",
 			},
 			"tenantid": {
 				Optional:    true,
@@ -70,30 +79,11 @@ func DataResource() *schema.Resource {
 func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	clientset := m.(*api.Clientset)
 
-	name := d.Get("name").(string)
-
-	var apiVPC *vpc.VPC
-
-	list, err := clientset.VPC().Get()
+	apiVPC, err := findVPC(clientset, d.Get("id").(string), d.Get("name").(string))
 	if err != nil {
 		return err
 	}
 
-	for _, v := range list {
-		if v.Name == name {
-			apiVPC = v
-			break
-		}
-	}
-
-	if apiVPC == nil {
-		return fmt.Errorf("coudn't find vpc '%s';", name)
-	}
-
-	if err != nil {
-		return nil
-	}
-
 	d.SetId(strconv.Itoa(apiVPC.ID))
 	err = d.Set("name", apiVPC.Name)
 	if err != nil {
@@ -123,6 +113,18 @@ func dataResourceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func findVPC(clientset *api.Clientset, id, name string) (*vpc.VPC, error) {
+	list, err := clientset.VPC().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Find(list, id, name,
+		func(v *vpc.VPC) int { return v.ID },
+		func(v *vpc.VPC) string { return v.Name },
+		"vpc", "id")
+}
+
 func dataResourceExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	clientset := m.(*api.Clientset)
 